@@ -0,0 +1,55 @@
+package yaredact
+
+import (
+	"reflect"
+	"sort"
+)
+
+// TestingT is the subset of testing.TB used by AssertRedacted
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// FindUnredacted walks value without modifying it and returns the sorted
+// paths of every leaf for which isSecretValue reports true, e.g. values that
+// still look like API keys after redaction. Paths use the same format as
+// RedactionRecord; nil leaves are never checked.
+func FindUnredacted(value any, isSecretValue func(any) bool) []string {
+	leaves := map[string]any{}
+	flattenValue(reflect.ValueOf(&value).Elem(), "", leaves, references{})
+
+	var paths []string
+	for path, leaf := range leaves {
+		if leaf != nil && isSecretValue(leaf) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// AssertRedacted fails the test for every leaf of value that isSecretValue
+// still considers a secret, so policy gaps are caught in CI, e.g.
+//
+//	yaredact.AssertRedacted(t, yaredact.Redact(cfg, isSensitive, redactValue), looksLikeKey)
+func AssertRedacted(t TestingT, value any, isSecretValue func(any) bool) {
+	t.Helper()
+	for _, path := range FindUnredacted(value, isSecretValue) {
+		t.Errorf("yaredact: value at %q was not redacted", path)
+	}
+}
+
+// RedactedEqual reports whether a and b are deeply equal once the values of
+// their sensitive fields and keys are masked, so values that differ only in
+// secrets compare equal, e.g. in snapshot tests with randomized secrets.
+func RedactedEqual[T any](a, b T, isSensitive func(string) bool, opts ...Option) bool {
+	// Mask every sensitive value with the zero value of its type
+	mask := func(v any) any {
+		if v == nil {
+			return nil
+		}
+		return reflect.Zero(reflect.TypeOf(v)).Interface()
+	}
+	return reflect.DeepEqual(Redact(a, isSensitive, mask, opts...), Redact(b, isSensitive, mask, opts...))
+}
@@ -0,0 +1,105 @@
+package yaredact
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recordingT captures failures reported by AssertRedacted
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFindUnredacted(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "Password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	looksLikeSecret := func(v any) bool {
+		s, ok := v.(string)
+		return ok && strings.HasPrefix(s, "sk_")
+	}
+
+	type Account struct {
+		Name     string
+		Password string
+		APIKey   string // missed by the policy
+		Tags     []string
+	}
+
+	account := Account{Name: "alice", Password: "sk_pass", APIKey: "sk_live_123", Tags: []string{"a", "sk_tag"}}
+	redacted := Redact(account, isSensitive, redactValue)
+
+	t.Run("Returns Missed Paths", func(t *testing.T) {
+		paths := FindUnredacted(redacted, looksLikeSecret)
+
+		expected := []string{"APIKey", "Tags[1]"}
+		if fmt.Sprint(paths) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+		if account.APIKey != "sk_live_123" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Fully Redacted", func(t *testing.T) {
+		paths := FindUnredacted(map[string]any{"Password": "***REDACTED***", "Name": "alice"}, looksLikeSecret)
+
+		if len(paths) != 0 {
+			t.Errorf("Expected no paths, got %v", paths)
+		}
+	})
+
+	t.Run("AssertRedacted", func(t *testing.T) {
+		rt := &recordingT{}
+		AssertRedacted(rt, &redacted, looksLikeSecret)
+
+		if len(rt.errors) != 2 || !strings.Contains(rt.errors[0], `"APIKey"`) {
+			t.Errorf("Expected failures for APIKey and Tags[1], got %v", rt.errors)
+		}
+	})
+}
+
+func TestRedactedEqual(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "pin"
+	}
+
+	type User struct {
+		Name     string
+		Password string
+		Pin      *int
+		Meta     map[string]string
+	}
+
+	pin1, pin2 := 1234, 9876
+	a := User{Name: "alice", Password: "random-1", Pin: &pin1, Meta: map[string]string{"password": "x"}}
+	b := User{Name: "alice", Password: "random-2", Pin: &pin2, Meta: map[string]string{"password": "y"}}
+
+	if !RedactedEqual(a, b, isSensitive) {
+		t.Errorf("Expected values differing only in secrets to be equal")
+	}
+
+	b.Name = "bob"
+	if RedactedEqual(a, b, isSensitive) {
+		t.Errorf("Expected values differing in Name not to be equal")
+	}
+	if a.Password != "random-1" {
+		t.Errorf("Original was modified")
+	}
+}
@@ -0,0 +1,36 @@
+package yaredact
+
+import (
+	"math/big"
+	"reflect"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+// isBigType reports whether t is big.Int, big.Float or big.Rat. Their values
+// are atomic leaves: copied deeply when not sensitive and handed to
+// redactValue whole, e.g. as a *big.Int, when sensitive.
+func isBigType(t reflect.Type) bool {
+	return t == bigIntType || t == bigFloatType || t == bigRatType
+}
+
+// copyBig returns a deep copy of a big.Int, big.Float or big.Rat value.
+// Copying the struct alone would share its digits with the original.
+func copyBig(v reflect.Value) reflect.Value {
+	if !v.CanInterface() {
+		return v
+	}
+	switch x := v.Interface().(type) {
+	case big.Int:
+		return reflect.ValueOf(new(big.Int).Set(&x)).Elem()
+	case big.Float:
+		return reflect.ValueOf(new(big.Float).Copy(&x)).Elem()
+	case big.Rat:
+		return reflect.ValueOf(new(big.Rat).Set(&x)).Elem()
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package yaredact
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBigNumbers(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "secret"
+	}
+
+	var received []any
+	redactValue := func(v any) any {
+		received = append(received, v)
+		switch v.(type) {
+		case *big.Int:
+			return new(big.Int)
+		}
+		return v
+	}
+
+	type Wallet struct {
+		Balance *big.Int
+		Secret  *big.Int
+		Rate    big.Rat
+		Price   *big.Float
+		Extra   map[string]*big.Int
+	}
+
+	balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	wallet := Wallet{
+		Balance: balance,
+		Secret:  big.NewInt(42),
+		Rate:    *big.NewRat(1, 3),
+		Price:   big.NewFloat(1.5),
+		Extra:   map[string]*big.Int{"secret": big.NewInt(7)},
+	}
+
+	result := Redact(wallet, isSensitive, redactValue)
+
+	if result.Balance == wallet.Balance || result.Balance.Cmp(balance) != 0 {
+		t.Errorf("Expected Balance to be a distinct copy of %s, got %s", balance, result.Balance)
+	}
+	if result.Secret.Sign() != 0 {
+		t.Errorf("Expected Secret to be redacted to zero, got %s", result.Secret)
+	}
+	if result.Rate.Cmp(big.NewRat(1, 3)) != 0 || result.Price.Cmp(big.NewFloat(1.5)) != 0 {
+		t.Errorf("Expected Rate and Price to be copied, got %s and %s", result.Rate.String(), result.Price)
+	}
+	if result.Extra["secret"].Sign() != 0 {
+		t.Errorf("Expected sensitive map value to be redacted, got %s", result.Extra["secret"])
+	}
+	for _, v := range received {
+		if _, ok := v.(*big.Int); !ok {
+			t.Errorf("Expected redactValue to receive a whole *big.Int, got %T", v)
+		}
+	}
+
+	// The copy doesn't share digits with the original
+	result.Balance.Add(result.Balance, big.NewInt(1))
+	if wallet.Balance.String() != "123456789012345678901234567890" || wallet.Secret.Int64() != 42 {
+		t.Errorf("Original was modified: %s", wallet.Balance)
+	}
+}
@@ -0,0 +1,26 @@
+package yaredact
+
+// RedactChannelSnapshot returns redacted copies of the items currently
+// buffered in ch, e.g. for a debug dump, leaving ch's contents in place.
+// It receives the buffered items without blocking and sends them back in
+// the same order before redacting them.
+//
+// The snapshot isn't atomic: items sent or received by other goroutines
+// while it runs may be missed, interleaved with the re-sent items or cause
+// the re-sending to block until there is room. It is meant for channels
+// that are quiescent, e.g. while their consumer is paused.
+func RedactChannelSnapshot[T any](ch chan T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) []T {
+	var items []T
+	for n := len(ch); len(items) < n; {
+		select {
+		case item := <-ch:
+			items = append(items, item)
+		default:
+			n = len(items)
+		}
+	}
+	for _, item := range items {
+		ch <- item
+	}
+	return Redact(items, isSensitive, redactValue, opts...)
+}
@@ -0,0 +1,56 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactChannelSnapshot(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Login struct {
+		User     string
+		Password string
+	}
+
+	t.Run("Buffered", func(t *testing.T) {
+		ch := make(chan Login, 4)
+		ch <- Login{User: "alice", Password: "a"}
+		ch <- Login{User: "bob", Password: "b"}
+
+		snapshot := RedactChannelSnapshot(ch, isSensitive, redactValue)
+
+		expected := []Login{{User: "alice", Password: "***REDACTED***"}, {User: "bob", Password: "***REDACTED***"}}
+		if len(snapshot) != 2 || snapshot[0] != expected[0] || snapshot[1] != expected[1] {
+			t.Errorf("Expected %+v, got %+v", expected, snapshot)
+		}
+
+		if len(ch) != 2 {
+			t.Fatalf("Expected the channel to keep its 2 items, got %d", len(ch))
+		}
+		if first := <-ch; first != (Login{User: "alice", Password: "a"}) {
+			t.Errorf("Expected the original items in order, got %+v", first)
+		}
+		if second := <-ch; second != (Login{User: "bob", Password: "b"}) {
+			t.Errorf("Expected the original items in order, got %+v", second)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if snapshot := RedactChannelSnapshot(make(chan Login, 1), isSensitive, redactValue); len(snapshot) != 0 {
+			t.Errorf("Expected an empty snapshot, got %+v", snapshot)
+		}
+		if snapshot := RedactChannelSnapshot(make(chan Login), isSensitive, redactValue); len(snapshot) != 0 {
+			t.Errorf("Expected an empty snapshot of an unbuffered channel, got %+v", snapshot)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package yaredact
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	mathrand "math/rand"
+	"strings"
+	"sync"
+)
+
+// encryptedPrefix marks values produced by EncryptRedactor
+const encryptedPrefix = "enc:"
+
+// hashedPrefix marks values produced by SaltedHashRedactor
+const hashedPrefix = "hmac:"
+
+// hashedBytes is how many bytes of the HMAC are kept in a token
+const hashedBytes = 8
+
+// EncryptRedactor returns a redactValue function that encrypts string values
+// with AES-GCM, producing "enc:" followed by the base64-encoded nonce and
+// ciphertext. The key must be 16, 24 or 32 bytes long (AES-128/192/256).
+// Use Decrypt with the same key to recover the original. Non-string values
+// pass through.
+func EncryptRedactor(key []byte) (func(any) any, error) {
+	return encryptRedactor(key, rand.Reader)
+}
+
+// EncryptRedactorWithRandSource is like EncryptRedactor but draws nonces from
+// src, so the same key, source seed and inputs produce the same ciphertexts,
+// e.g. for stable test output. Predictable nonces are insecure: use it in
+// tests only, never for production data.
+func EncryptRedactorWithRandSource(key []byte, src mathrand.Source) (func(any) any, error) {
+	return encryptRedactor(key, &sourceReader{rand: mathrand.New(src)})
+}
+
+// sourceReader reads bytes from a math/rand generator, serializing calls so
+// the redactor stays safe for concurrent use
+type sourceReader struct {
+	mu   sync.Mutex
+	rand *mathrand.Rand
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range p {
+		p[i] = byte(r.rand.Intn(256))
+	}
+	return len(p), nil
+}
+
+func encryptRedactor(key []byte, random io.Reader) (func(any) any, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(random, nonce); err != nil {
+			// Never fall back to the plaintext
+			return encryptedPrefix
+		}
+		sealed := gcm.Seal(nonce, nonce, []byte(s), nil)
+		return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}, nil
+}
+
+// Decrypt recovers the original string from a value produced by
+// EncryptRedactor with the same key
+func Decrypt(key []byte, s string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(s, encryptedPrefix) {
+		return "", errors.New("yaredact: value is not encrypted")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, encryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("yaredact: encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SaltedHashRedactor returns a redactValue function that replaces string
+// values with "hmac:" followed by a hex prefix of their HMAC-SHA256 under
+// salt. Equal inputs produce equal tokens, so distinct secrets can be counted,
+// but tokens can't be reversed or linked across runs that use different
+// salts. Use a random salt per run, e.g. from crypto/rand. Non-string values
+// pass through.
+func SaltedHashRedactor(salt []byte) func(any) any {
+	// Copy the salt so later changes by the caller don't affect tokens
+	key := append([]byte(nil), salt...)
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(s))
+		return hashedPrefix + hex.EncodeToString(mac.Sum(nil)[:hashedBytes])
+	}
+}
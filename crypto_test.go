@@ -0,0 +1,122 @@
+package yaredact
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestEncryptRedactor(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	type User struct {
+		Name     string
+		Password string
+	}
+
+	t.Run("Round Trip", func(t *testing.T) {
+		redactValue, err := EncryptRedactor(key)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		result := Redact(User{Name: "John", Password: "secret123"}, isSensitive, redactValue)
+
+		if !strings.HasPrefix(result.Password, "enc:") {
+			t.Fatalf("Expected Password to be encrypted, got %s", result.Password)
+		}
+		if strings.Contains(result.Password, "secret123") {
+			t.Errorf("Expected ciphertext not to contain the plaintext")
+		}
+
+		plaintext, err := Decrypt(key, result.Password)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if plaintext != "secret123" {
+			t.Errorf("Expected 'secret123', got %s", plaintext)
+		}
+	})
+
+	t.Run("Non-String Passes Through", func(t *testing.T) {
+		redactValue, _ := EncryptRedactor(key)
+		if got := redactValue(42); got != 42 {
+			t.Errorf("Expected non-string to pass through, got %v", got)
+		}
+	})
+
+	t.Run("Invalid Key Length", func(t *testing.T) {
+		if _, err := EncryptRedactor([]byte("short")); err == nil {
+			t.Errorf("Expected an error for an invalid key length")
+		}
+	})
+
+	t.Run("Rand Source", func(t *testing.T) {
+		run := func() User {
+			redactValue, err := EncryptRedactorWithRandSource(key, rand.NewSource(1))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			return Redact(User{Name: "John", Password: "secret123"}, isSensitive, redactValue)
+		}
+
+		first, second := run(), run()
+		if first.Password != second.Password {
+			t.Errorf("Expected identical output for the same source, got %s and %s", first.Password, second.Password)
+		}
+
+		plaintext, err := Decrypt(key, first.Password)
+		if err != nil || plaintext != "secret123" {
+			t.Errorf("Expected 'secret123', got %q (%v)", plaintext, err)
+		}
+
+		redactValue, _ := EncryptRedactorWithRandSource(key, rand.NewSource(1))
+		if redactValue("a") == redactValue("a") {
+			t.Errorf("Expected nonces to keep varying within a run")
+		}
+	})
+
+	t.Run("Wrong Key", func(t *testing.T) {
+		redactValue, _ := EncryptRedactor(key)
+		encrypted := redactValue("secret123").(string)
+
+		if _, err := Decrypt([]byte("fedcba9876543210fedcba9876543210"), encrypted); err == nil {
+			t.Errorf("Expected an error decrypting with the wrong key")
+		}
+		if _, err := Decrypt(key, "secret123"); err == nil {
+			t.Errorf("Expected an error decrypting an unencrypted value")
+		}
+	})
+}
+
+func TestSaltedHashRedactor(t *testing.T) {
+	redactValue := SaltedHashRedactor([]byte("run-salt"))
+
+	first := redactValue("secret123")
+	second := redactValue("secret123")
+	other := redactValue("secret124")
+
+	if first != second {
+		t.Errorf("Expected equal inputs to produce equal tokens, got %v and %v", first, second)
+	}
+	if first == other {
+		t.Errorf("Expected different inputs to produce different tokens, got %v", first)
+	}
+	if s, ok := first.(string); !ok || !strings.HasPrefix(s, "hmac:") || len(s) != len("hmac:")+16 {
+		t.Errorf("Expected an hmac: token with 16 hex digits, got %v", first)
+	}
+	if strings.Contains(first.(string), "secret123") {
+		t.Errorf("Expected token not to contain the input")
+	}
+
+	if otherSalt := SaltedHashRedactor([]byte("other-salt"))("secret123"); otherSalt == first {
+		t.Errorf("Expected different salts to produce different tokens")
+	}
+	if redactValue(42) != 42 {
+		t.Errorf("Expected non-string values to pass through")
+	}
+}
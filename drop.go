@@ -0,0 +1,8 @@
+package yaredact
+
+type dropSentinel struct{}
+
+// Drop can be returned by redactValue to remove a value instead of masking it.
+// Sensitive map entries are omitted from the result map. Struct fields can't
+// be removed, so they are left as their zero value instead.
+var Drop any = &dropSentinel{}
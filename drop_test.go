@@ -0,0 +1,66 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDrop(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token"
+	}
+
+	dropAll := func(v any) any {
+		return Drop
+	}
+
+	t.Run("Map Entries Are Omitted", func(t *testing.T) {
+		data := map[string]any{"name": "John", "password": "secret123", "token": 42}
+
+		result := Redact(data, isSensitive, dropAll)
+
+		if len(result) != 1 || result["name"] != "John" {
+			t.Errorf("Expected only name to remain, got %v", result)
+		}
+		if _, ok := result["password"]; ok {
+			t.Errorf("Expected password to be dropped")
+		}
+	})
+
+	t.Run("Struct Fields Are Zeroed", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+			Token    *string
+		}
+
+		token := "abc123"
+		user := User{Name: "John", Password: "secret123", Token: &token}
+
+		result := Redact(user, isSensitive, dropAll)
+
+		if result.Name != "John" {
+			t.Errorf("Expected Name to be kept, got %s", result.Name)
+		}
+		if result.Password != "" {
+			t.Errorf("Expected Password to be zeroed, got %s", result.Password)
+		}
+		if result.Token != nil {
+			t.Errorf("Expected Token pointer to be nil, got %v", *result.Token)
+		}
+		if user.Password != "secret123" || *user.Token != "abc123" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Nil Result For Map Of Any", func(t *testing.T) {
+		data := map[string]any{"password": "secret123"}
+
+		result := Redact(data, isSensitive, func(v any) any { return nil })
+
+		if v, ok := result["password"]; !ok || v != nil {
+			t.Errorf("Expected password to be kept with a nil value, got %v", result)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package yaredact
+
+import (
+	"reflect"
+	"sort"
+)
+
+// DryRun returns the sorted paths of the values Redact would pass to
+// redactValue under isSensitive and opts, without modifying arg, to preview
+// a policy. Paths use the same format as RedactionRecord.
+//
+// DryRun traverses arg with the same walker as Redact, so the paths always
+// agree, but no user callback that produces output is invoked: sensitive
+// values are kept as they are, registered type handlers are not called and
+// their values are reported as redacted, and options that only change how
+// values are redacted, such as WithRedactValue, WithMetrics, WithPostProcess
+// or WithScrubSubstrings, are ignored. The walk still builds and discards an
+// unredacted copy of arg, so it costs about as much as Redact itself.
+func DryRun[T any](arg T, isSensitive func(string) bool, opts ...Option) []string {
+	paths := []string{}
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return paths
+	}
+
+	identity := func(v any) any { return v }
+	dryRun := func(c *config) {
+		c.redactValue = identity
+		c.piiRedactors = nil
+		c.maxRedactions = 0
+		c.metrics = nil
+		c.postProcess = nil
+		c.scrubPatterns = nil
+		c.preservePointerIdentity = false
+		handlers := make(map[reflect.Type]func(any) any, len(c.typeHandlers))
+		for t := range c.typeHandlers {
+			handlers[t] = identity
+		}
+		c.typeHandlers = handlers
+		policies := make(map[string]Policy, len(c.policies))
+		for name, policy := range c.policies {
+			policies[name] = Policy{IsSensitive: policy.IsSensitive}
+		}
+		c.policies = policies
+	}
+
+	w := newWalker(newConfig(isSensitive, identity, append(opts[:len(opts):len(opts)], dryRun)))
+	w.report = true
+	w.redactRoot(reflect.ValueOf(arg))
+	for _, record := range w.records {
+		paths = append(paths, record.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
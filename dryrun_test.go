@@ -0,0 +1,145 @@
+package yaredact
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDryRun(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token" || lower == "secret"
+	}
+
+	type Session struct {
+		Token   string
+		Created time.Time
+	}
+
+	type User struct {
+		Name     string
+		Password *string
+		Sessions []Session
+		Meta     map[string]any
+		Err      error
+		secret   string
+	}
+
+	password := "hunter2"
+	user := &User{
+		Name:     "alice",
+		Password: &password,
+		Sessions: []Session{{Token: "t1"}, {Token: "t2"}},
+		Meta:     map[string]any{"secret": "s", "nested": map[string]string{"token": "x", "region": "eu"}, "password": []string{"a", "b"}},
+		Err:      fmt.Errorf("token expired"),
+		secret:   "hidden",
+	}
+
+	paths := DryRun(user, isSensitive)
+
+	expected := []string{"Meta.nested.token", "Meta.password[0]", "Meta.password[1]", "Meta.secret", "Password", "Sessions[0].Token", "Sessions[1].Token"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+	if password != "hunter2" || user.Sessions[0].Token != "t1" {
+		t.Errorf("Original was modified")
+	}
+
+	// The reported paths match what Redact actually redacts
+	identity := func(v any) any { return v }
+	_, records := RedactWithReport(user, isSensitive, identity)
+	if len(records) != len(expected) {
+		t.Errorf("Expected %d redactions, got %d", len(expected), len(records))
+	}
+	for _, record := range records {
+		found := false
+		for _, path := range paths {
+			found = found || path == record.Path
+		}
+		if !found {
+			t.Errorf("Expected %s to be reported by DryRun", record.Path)
+		}
+	}
+}
+
+func TestDryRunMatchesRedact(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "password" || name == "token"
+	}
+
+	type Plugin struct {
+		Password string
+		Config   json.RawMessage
+		Cache    *sync.Map
+		Extra    map[string]**string
+	}
+
+	var cache sync.Map
+	cache.Store("token", "abc")
+	var missing *string
+	plugin := Plugin{
+		Password: "hunter2",
+		Config:   json.RawMessage(`{"url":"https://example.com","token":"t"}`),
+		Cache:    &cache,
+		Extra:    map[string]**string{"password": &missing},
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		if paths := DryRun(plugin, isSensitive); len(paths) != 2 {
+			t.Errorf("Expected only the lowercase keys to match without options, got %v", paths)
+		}
+
+		paths := DryRun(plugin, isSensitive, WithNormalizeName(strings.ToLower))
+
+		expected := []string{"Cache.token", "Config.token", "Password"}
+		if fmt.Sprint(paths) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("Reported By Redact", func(t *testing.T) {
+		opts := []Option{WithNormalizeName(strings.ToLower)}
+		paths := DryRun(plugin, isSensitive, opts...)
+
+		_, records := RedactWithReport(plugin, isSensitive, func(v any) any { return v }, opts...)
+		var reported []string
+		for _, record := range records {
+			reported = append(reported, record.Path)
+		}
+		sort.Strings(reported)
+		if fmt.Sprint(paths) != fmt.Sprint(reported) {
+			t.Errorf("Expected DryRun %v to match the report %v", paths, reported)
+		}
+	})
+	t.Run("No Output Callbacks", func(t *testing.T) {
+		type Token struct {
+			Value string
+		}
+		type Holder struct {
+			Password string
+			Auth     Token
+		}
+
+		called := false
+		handlers := map[reflect.Type]func(any) any{
+			reflect.TypeOf(Token{}): func(v any) any { called = true; return Token{Value: "masked"} },
+		}
+		redactValue := func(v any) any { called = true; return v }
+
+		paths := DryRun(Holder{Password: "p", Auth: Token{Value: "t"}}, isSensitive,
+			WithTypeHandlers(handlers), WithRedactValue(redactValue), WithNormalizeName(strings.ToLower))
+
+		if called {
+			t.Errorf("Expected no redaction callback to be invoked")
+		}
+		if expected := []string{"Auth", "Password"}; fmt.Sprint(paths) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+	})
+}
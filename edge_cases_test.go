@@ -1,8 +1,11 @@
 package yaredact
 
 import (
+	"net"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestEdgeCases tests various edge cases and potential issues
@@ -189,4 +192,435 @@ func TestEdgeCases(t *testing.T) {
 			t.Errorf("Expected case-insensitive match, got %s", result.PASSWORD)
 		}
 	})
+
+	t.Run("Struct With Channel And Func Fields", func(t *testing.T) {
+		type Worker struct {
+			Jobs     chan string
+			OnDone   func() string
+			done     chan struct{} // unexported
+			Password string
+		}
+
+		jobs := make(chan string, 1)
+		jobs <- "job-1"
+		worker := Worker{
+			Jobs:     jobs,
+			OnDone:   func() string { return "done" },
+			done:     make(chan struct{}),
+			Password: "secret123",
+		}
+
+		result := Redact(worker, isSensitive, redactValue)
+
+		if result.Jobs != jobs {
+			t.Errorf("Expected channel to be preserved as-is")
+		}
+		if len(result.Jobs) != 1 {
+			t.Errorf("Expected channel contents to be untouched, got len %d", len(result.Jobs))
+		}
+		if result.OnDone == nil || result.OnDone() != "done" {
+			t.Errorf("Expected func to be preserved as-is")
+		}
+		if result.Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted, got %s", result.Password)
+		}
+	})
+
+	t.Run("Pointer To Pointer Sensitive Field", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password **string
+			Secret   ***string
+		}
+
+		pass := "secret123"
+		passPtr := &pass
+		secret := "hidden"
+		secretPtr := &secret
+		secretPtrPtr := &secretPtr
+
+		user := User{Name: "John", Password: &passPtr, Secret: &secretPtrPtr}
+
+		result := Redact(user, isSensitive, redactValue)
+
+		if result.Password == nil || *result.Password == nil {
+			t.Fatalf("Expected Password indirection to be preserved")
+		}
+		if **result.Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted, got %s", **result.Password)
+		}
+		if ***result.Secret != "***REDACTED***" {
+			t.Errorf("Expected Secret to be redacted, got %s", ***result.Secret)
+		}
+		if **user.Password != "secret123" || ***user.Secret != "hidden" {
+			t.Errorf("Original values were modified")
+		}
+	})
+
+	t.Run("Pointer To Nil Pointer Sensitive Field", func(t *testing.T) {
+		type User struct {
+			Password **string
+		}
+
+		var inner *string
+		user := User{Password: &inner}
+
+		result := Redact(user, isSensitive, redactValue)
+
+		if result.Password == nil {
+			t.Fatalf("Expected outer pointer to be preserved")
+		}
+		if *result.Password != nil {
+			t.Errorf("Expected inner nil pointer to stay nil")
+		}
+	})
+
+	t.Run("Slice Of Struct Pointers With Nil Entries", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		users := []*User{
+			{Name: "Alice", Password: "alice-pass"},
+			nil,
+			{Name: "Bob", Password: "bob-pass"},
+			nil,
+		}
+
+		result := Redact(users, isSensitive, redactValue)
+
+		if len(result) != 4 {
+			t.Fatalf("Expected 4 entries, got %d", len(result))
+		}
+		if result[1] != nil || result[3] != nil {
+			t.Errorf("Expected nil entries to be preserved at their indices")
+		}
+		for _, i := range []int{0, 2} {
+			if result[i] == users[i] {
+				t.Errorf("Expected result[%d] to be a new pointer", i)
+			}
+			if result[i].Password != "***REDACTED***" {
+				t.Errorf("Expected result[%d].Password to be redacted, got %s", i, result[i].Password)
+			}
+			if result[i].Name != users[i].Name {
+				t.Errorf("Expected result[%d].Name to be %s, got %s", i, users[i].Name, result[i].Name)
+			}
+		}
+		if users[0].Password != "alice-pass" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Slice Of Maps With Stringer Keys", func(t *testing.T) {
+		data := []map[edgeCaseKey]string{
+			{{Name: "password"}: "hunter2", {Name: "user"}: "alice"},
+			nil,
+			{{Name: "secret"}: "s3"},
+		}
+
+		result := Redact(data, isSensitive, redactValue)
+
+		if len(result) != 3 {
+			t.Fatalf("Expected 3 entries, got %d", len(result))
+		}
+		if result[1] != nil {
+			t.Errorf("Expected nil map entry to be preserved")
+		}
+		if result[0][edgeCaseKey{Name: "password"}] != "***REDACTED***" {
+			t.Errorf("Expected password key to be redacted, got %s", result[0][edgeCaseKey{Name: "password"}])
+		}
+		if result[0][edgeCaseKey{Name: "user"}] != "alice" {
+			t.Errorf("Expected user key to be kept, got %s", result[0][edgeCaseKey{Name: "user"}])
+		}
+		if result[2][edgeCaseKey{Name: "secret"}] != "***REDACTED***" {
+			t.Errorf("Expected secret key to be redacted, got %s", result[2][edgeCaseKey{Name: "secret"}])
+		}
+		if data[0][edgeCaseKey{Name: "password"}] != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Typed Nil Roots And Interfaces", func(t *testing.T) {
+		matchAll := WithErrorMatcher(func(string) bool { return true })
+
+		type Result struct {
+			Err   error
+			Value any
+		}
+
+		var typedNil error = (*edgeCaseError)(nil)
+		var nilUser *Result
+
+		if result := Redact(typedNil, isSensitive, redactValue, matchAll); result != typedNil {
+			t.Errorf("Expected typed-nil error root to be returned as-is, got %v", result)
+		}
+		if result := Redact[any](typedNil, isSensitive, redactValue, matchAll); result != typedNil {
+			t.Errorf("Expected typed-nil error boxed in any to be returned as-is, got %v", result)
+		}
+		if result := Redact(nilUser, isSensitive, redactValue, WithPreservePointerIdentity()); result != nil {
+			t.Errorf("Expected typed-nil pointer root to stay nil, got %v", result)
+		}
+		if result := Redact[any](nil, isSensitive, redactValue); result != nil {
+			t.Errorf("Expected nil interface root to stay nil, got %v", result)
+		}
+		if result := RedactAny(nilUser, isSensitive, redactValue); result.(*Result) != nil {
+			t.Errorf("Expected typed-nil pointer to stay nil, got %v", result)
+		}
+
+		result := Redact(Result{Err: typedNil, Value: nilUser}, isSensitive, redactValue, matchAll)
+		if result.Err != typedNil {
+			t.Errorf("Expected typed-nil error field to be copied as-is, got %v", result.Err)
+		}
+		if result.Value.(*Result) != nil {
+			t.Errorf("Expected typed-nil pointer in any field to stay nil, got %v", result.Value)
+		}
+	})
+
+	t.Run("Named Numeric And String Sensitive Fields", func(t *testing.T) {
+		type Level string
+
+		type Session struct {
+			Secret   time.Duration
+			Password Level
+			Timeout  time.Duration
+		}
+
+		// Returns plain int64 and string values, not the named field types
+		plainRedactValue := func(v any) any {
+			switch v.(type) {
+			case time.Duration:
+				return int64(0)
+			case Level:
+				return "***REDACTED***"
+			}
+			return v
+		}
+
+		session := Session{Secret: 5 * time.Second, Password: "admin", Timeout: time.Minute}
+
+		result := Redact(session, isSensitive, plainRedactValue)
+
+		if result.Secret != 0 {
+			t.Errorf("Expected Secret to be masked to zero duration, got %v", result.Secret)
+		}
+		if result.Password != "***REDACTED***" {
+			t.Errorf("Expected named string to be redacted, got %s", result.Password)
+		}
+		if result.Timeout != time.Minute {
+			t.Errorf("Expected Timeout to be kept, got %v", result.Timeout)
+		}
+	})
+
+	t.Run("Slices Do Not Alias The Input", func(t *testing.T) {
+		type Host struct {
+			Names []string
+			Raw   [][]byte
+			IP    net.IP
+			Ports [2]int
+		}
+
+		backing := make([]string, 2, 4)
+		backing[0], backing[1] = "a", "b"
+		host := Host{
+			Names: backing,
+			Raw:   [][]byte{[]byte("raw")},
+			IP:    net.ParseIP("10.0.0.1"),
+			Ports: [2]int{80, 443},
+		}
+
+		result := Redact(host, isSensitive, redactValue)
+
+		if len(result.Names) != 2 || cap(result.Names) != 4 {
+			t.Errorf("Expected length 2 and capacity 4, got %d and %d", len(result.Names), cap(result.Names))
+		}
+
+		passwords := make([]string, 1, 3)
+		passwords[0] = "hunter2"
+		form := Redact(map[string][]string{"password": passwords}, isSensitive, redactValue)
+		if got := form["password"]; len(got) != 1 || cap(got) != 3 || got[0] != "***REDACTED***" {
+			t.Errorf("Expected redacted list with length 1 and capacity 3, got %v with capacity %d", got, cap(got))
+		}
+
+		result.Names[0] = "changed"
+		result.Names = append(result.Names, "appended")
+		result.Raw[0][0] = 'X'
+		result.IP[len(result.IP)-1] = 99
+		result.Ports[0] = 8080
+
+		if host.Names[0] != "a" || backing[:3][2] != "" {
+			t.Errorf("Expected input slice and its spare capacity to be untouched, got %v", backing[:3])
+		}
+		if string(host.Raw[0]) != "raw" {
+			t.Errorf("Expected nested byte slice to be untouched, got %s", host.Raw[0])
+		}
+		if !host.IP.Equal(net.ParseIP("10.0.0.1")) {
+			t.Errorf("Expected net.IP to be untouched, got %v", host.IP)
+		}
+		if host.Ports[0] != 80 {
+			t.Errorf("Expected array to be untouched, got %v", host.Ports)
+		}
+	})
+
+	t.Run("Generic Container", func(t *testing.T) {
+		type Credentials struct {
+			User     string
+			Password string
+		}
+
+		box := edgeCaseBox[Credentials]{Value: Credentials{User: "admin", Password: "hunter2"}, Meta: "m"}
+		result := Redact(box, isSensitive, redactValue)
+
+		if result.Value.Password != "***REDACTED***" || result.Value.User != "admin" || result.Meta != "m" {
+			t.Errorf("Expected inner Password of Box[Credentials] to be redacted, got %+v", result)
+		}
+		if box.Value.Password != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+
+		pointers := []edgeCaseBox[*Credentials]{{Value: &Credentials{Password: "p"}}, {}}
+		pointerResult := Redact(pointers, isSensitive, redactValue)
+		if pointerResult[0].Value.Password != "***REDACTED***" || pointerResult[1].Value != nil {
+			t.Errorf("Expected Box[*Credentials] to be redacted, got %+v", pointerResult)
+		}
+
+		nested := map[string]edgeCaseBox[edgeCaseBox[string]]{"password": {Value: edgeCaseBox[string]{Value: "x"}}}
+		nestedResult := Redact(nested, isSensitive, redactValue)
+		if nestedResult["password"].Value.Value != "x" {
+			t.Errorf("Expected a sensitive Box returned unchanged by redactValue to be kept, got %+v", nestedResult)
+		}
+
+		secrets := edgeCaseBox[map[string]string]{Value: map[string]string{"secret": "s"}}
+		if got := Redact(secrets, isSensitive, redactValue); got.Value["secret"] != "***REDACTED***" {
+			t.Errorf("Expected Box[map[string]string] to be redacted, got %+v", got)
+		}
+	})
+
+	t.Run("Recursive Types", func(t *testing.T) {
+		type Node struct {
+			Name     string
+			Secret   string
+			Children []*Node
+			Parent   *Node
+		}
+
+		leaf := &Node{Name: "leaf", Secret: "s3"}
+		middle := &Node{Name: "middle", Secret: "s2", Children: []*Node{leaf}}
+		root := &Node{Name: "root", Secret: "s1", Children: []*Node{middle, {Name: "empty"}}}
+
+		result := Redact(root, isSensitive, redactValue)
+
+		var walk func(n *Node, depth int) int
+		walk = func(n *Node, depth int) int {
+			if n.Secret != "" && n.Secret != "***REDACTED***" {
+				t.Errorf("Expected %s's secret to be redacted, got %s", n.Name, n.Secret)
+			}
+			deepest := depth
+			for _, child := range n.Children {
+				deepest = max(deepest, walk(child, depth+1))
+			}
+			return deepest
+		}
+		if depth := walk(result, 1); depth != 3 {
+			t.Errorf("Expected all 3 levels to be copied, got %d", depth)
+		}
+		if leaf.Secret != "s3" {
+			t.Errorf("Original was modified")
+		}
+
+		// Malformed trees with back references terminate, and the copy
+		// points back into itself rather than into the original
+		leaf.Parent = middle
+		leaf.Children = []*Node{root}
+		cyclic := Redact(root, isSensitive, redactValue)
+		copiedMiddle := cyclic.Children[0]
+		copiedLeaf := copiedMiddle.Children[0]
+		if copiedLeaf.Parent != copiedMiddle || copiedLeaf.Children[0] != cyclic {
+			t.Errorf("Expected back references to point into the copy")
+		}
+		if copiedLeaf.Secret != "***REDACTED***" {
+			t.Errorf("Expected secret in a cyclic tree to be redacted, got %s", copiedLeaf.Secret)
+		}
+
+		self := map[string]any{"secret": "s"}
+		self["self"] = self
+		copied := Redact(self, isSensitive, redactValue)
+		if copied["secret"] != "***REDACTED***" || reflect.ValueOf(copied["self"]).Pointer() != reflect.ValueOf(copied).Pointer() {
+			t.Errorf("Expected a self-referencing map to be copied with its cycle, got %v", copied["secret"])
+		}
+
+		list := []any{"s", map[string]any{"secret": "s"}}
+		list[0] = list
+		copiedList := Redact(list, isSensitive, redactValue)
+		if inner, ok := copiedList[0].([]any); !ok || &inner[0] != &copiedList[0] {
+			t.Errorf("Expected a self-containing slice to be copied with its cycle")
+		}
+		if copiedList[1].(map[string]any)["secret"] != "***REDACTED***" {
+			t.Errorf("Expected secret next to a slice cycle to be redacted, got %v", copiedList[1])
+		}
+		if flattened := RedactFlatten(list, isSensitive, redactValue); flattened["[1].secret"] != "***REDACTED***" {
+			t.Errorf("Expected a self-containing slice to be flattened once, got %v", flattened)
+		}
+		mergedList := MergeUnredacted(list, copiedList, "***REDACTED***")
+		if mergedList[1].(map[string]any)["secret"] != "s" {
+			t.Errorf("Expected secret next to a slice cycle to be merged back, got %v", mergedList[1])
+		}
+		if paths := DryRun(list, isSensitive); len(paths) != 1 {
+			t.Errorf("Expected the secret next to a slice cycle to be listed once, got %v", paths)
+		}
+		StructureHash(copiedList)
+
+		// Read-only traversals stop where a cycle closes
+		flattened := RedactFlatten(root, isSensitive, redactValue)
+		if flattened["Children[0].Children[0].Secret"] != "***REDACTED***" || flattened["Children[0].Children[0].Parent.Name"] != nil {
+			t.Errorf("Expected the cyclic tree to be flattened once, got %v", flattened)
+		}
+		if line := RedactToLogfmt(self, isSensitive, redactValue); line != "secret=***REDACTED***" {
+			t.Errorf("Expected a self-referencing map to render once, got %s", line)
+		}
+		if paths := DryRun(root, isSensitive); len(paths) != 4 {
+			t.Errorf("Expected each node's secret to be listed once, got %v", paths)
+		}
+		isOriginal := func(v any) bool { return v == "s1" || v == "s2" || v == "s3" }
+		if paths := FindUnredacted(cyclic, isOriginal); len(paths) != 0 {
+			t.Errorf("Expected no unredacted secrets, got %v", paths)
+		}
+		AssertRedacted(t, cyclic, isOriginal)
+		if StructureHash(cyclic) != StructureHash(Redact(root, isSensitive, redactValue)) {
+			t.Errorf("Expected equal cyclic trees to hash equally")
+		}
+
+		merged := MergeUnredacted(root, cyclic, "***REDACTED***")
+		mergedLeaf := merged.Children[0].Children[0]
+		if merged.Secret != "s1" || mergedLeaf.Secret != "s3" {
+			t.Errorf("Expected secrets to be merged back, got %s and %s", merged.Secret, mergedLeaf.Secret)
+		}
+		if mergedLeaf.Children[0] != merged || mergedLeaf.Parent != merged.Children[0] {
+			t.Errorf("Expected back references to point into the merged copy")
+		}
+	})
+}
+
+// edgeCaseBox is a generic container
+type edgeCaseBox[T any] struct {
+	Value T
+	Meta  string
+}
+
+// edgeCaseError is an error whose Error method panics on a nil receiver
+type edgeCaseError struct {
+	msg string
+}
+
+func (e *edgeCaseError) Error() string {
+	return e.msg
+}
+
+// edgeCaseKey is a non-string map key that stringifies to its name
+type edgeCaseKey struct {
+	Name string
+}
+
+func (k edgeCaseKey) String() string {
+	return k.Name
 }
@@ -0,0 +1,56 @@
+package yaredact
+
+import (
+	"errors"
+	"reflect"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// redactedError replaces an error whose message was scrubbed. It keeps the
+// original error for errors.Is/errors.As matching without exposing its message.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+func (e *redactedError) As(target any) bool {
+	return errors.As(e.err, target)
+}
+
+// redactedErrorMessage replaces a matched error message that redactValue
+// didn't turn into a string
+const redactedErrorMessage = "***REDACTED***"
+
+// redactError handles an error value boxed in the interface value iface.
+// Errors are treated as opaque leaves: they are copied verbatim, unless an
+// error matcher is configured and matches the message, in which case the
+// message is redacted like any other sensitive value at path and the error
+// is wrapped. A matched error never keeps its original message: a dropped
+// message, or an interface type the wrapper can't satisfy, leaves a nil
+// error, and any other non-string result becomes redactedErrorMessage.
+func (w *walker) redactError(iface reflect.Value, err error, path string) reflect.Value {
+	if w.errorMatcher == nil || !w.errorMatcher(err.Error()) {
+		return iface
+	}
+
+	redacted := w.redact(path, err.Error())
+	w.record(path, "", iface.Elem().Kind())
+
+	wrapped := &redactedError{msg: redactedErrorMessage, err: err}
+	if redacted == Drop || !reflect.TypeOf(wrapped).AssignableTo(iface.Type()) {
+		return reflect.Zero(iface.Type())
+	}
+	if msg, ok := redacted.(string); ok {
+		wrapped.msg = msg
+	}
+	return reflect.ValueOf(wrapped)
+}
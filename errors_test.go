@@ -0,0 +1,121 @@
+package yaredact
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "secret"
+	}
+
+	errNotFound := errors.New("not found")
+
+	type Result struct {
+		Cause  error
+		Secret error
+		Other  error
+	}
+
+	result := Result{
+		Cause:  fmt.Errorf("lookup with token=abc123 failed: %w", errNotFound),
+		Secret: errors.New("password is hunter2"),
+		Other:  errors.New("connection refused"),
+	}
+
+	redactValue := func(v any) any {
+		switch v.(type) {
+		case string:
+			return "***REDACTED***"
+		case error:
+			return errors.New("***REDACTED ERROR***")
+		}
+		return v
+	}
+
+	t.Run("Errors Are Copied Verbatim", func(t *testing.T) {
+		redacted := Redact(result, isSensitive, redactValue)
+
+		if redacted.Cause.Error() != result.Cause.Error() {
+			t.Errorf("Expected Cause message to be preserved, got %q", redacted.Cause.Error())
+		}
+		if !errors.Is(redacted.Cause, errNotFound) {
+			t.Errorf("Expected Cause to still match errNotFound")
+		}
+		if redacted.Other.Error() != "connection refused" {
+			t.Errorf("Expected Other message to be preserved, got %q", redacted.Other.Error())
+		}
+	})
+
+	t.Run("Sensitive Error Field", func(t *testing.T) {
+		redacted := Redact(result, isSensitive, redactValue)
+
+		if redacted.Secret.Error() != "***REDACTED ERROR***" {
+			t.Errorf("Expected Secret error to be replaced, got %q", redacted.Secret.Error())
+		}
+	})
+
+	t.Run("Error Matcher", func(t *testing.T) {
+		containsToken := func(msg string) bool {
+			return strings.Contains(msg, "token=")
+		}
+
+		redacted := Redact(result, isSensitive, redactValue, WithErrorMatcher(containsToken))
+
+		if redacted.Cause.Error() != "***REDACTED***" {
+			t.Errorf("Expected Cause message to be redacted, got %q", redacted.Cause.Error())
+		}
+		if !errors.Is(redacted.Cause, errNotFound) {
+			t.Errorf("Expected redacted Cause to still match errNotFound")
+		}
+		if redacted.Other.Error() != "connection refused" {
+			t.Errorf("Expected non-matching error to be preserved, got %q", redacted.Other.Error())
+		}
+		if !strings.Contains(result.Cause.Error(), "token=abc123") {
+			t.Errorf("Original Cause was modified")
+		}
+	})
+	t.Run("Error Matcher With Options", func(t *testing.T) {
+		containsToken := func(msg string) bool {
+			return strings.Contains(msg, "token=")
+		}
+		postProcess := func(path string, redacted any) any {
+			return fmt.Sprintf("%s:%v", path, redacted)
+		}
+
+		redacted, records := RedactWithReport(result, isSensitive, redactValue,
+			WithErrorMatcher(containsToken), WithPostProcess(postProcess))
+
+		if redacted.Cause.Error() != "Cause:***REDACTED***" {
+			t.Errorf("Expected Cause message to be post-processed, got %q", redacted.Cause.Error())
+		}
+		found := false
+		for _, record := range records {
+			found = found || record.Path == "Cause"
+		}
+		if !found {
+			t.Errorf("Expected redacted Cause to be reported, got %+v", records)
+		}
+	})
+	t.Run("Error Matcher Fails Closed", func(t *testing.T) {
+		containsToken := func(msg string) bool {
+			return strings.Contains(msg, "token=")
+		}
+
+		dropped := Redact(result, isSensitive, func(any) any { return Drop }, WithErrorMatcher(containsToken))
+		if dropped.Cause != nil {
+			t.Errorf("Expected dropped error message to leave a nil error, got %q", dropped.Cause.Error())
+		}
+
+		nonString := Redact(result, isSensitive, func(any) any { return nil }, WithErrorMatcher(containsToken))
+		if nonString.Cause == nil || nonString.Cause.Error() != "***REDACTED***" {
+			t.Errorf("Expected non-string result to become a placeholder, got %v", nonString.Cause)
+		}
+		if !errors.Is(nonString.Cause, errNotFound) {
+			t.Errorf("Expected placeholder error to still match errNotFound")
+		}
+	})
+}
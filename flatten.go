@@ -0,0 +1,90 @@
+package yaredact
+
+import "reflect"
+
+// RedactFlatten redacts arg and flattens the result into dotted-path keys
+// suitable for structured loggers, e.g. {"Cred.Token": "***", "Tags[0]": "a"}.
+// Paths use the same format as RedactionRecord. Nil pointers and interfaces
+// become nil values, text-encodable values (time.Time, net.IP) and structs
+// without exported fields are kept whole, and empty maps and slices produce
+// no keys. A root value that isn't a container is stored under the empty key.
+// Cyclic references produce no keys past the point where the cycle closes.
+func RedactFlatten[T any](arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) map[string]any {
+	result := map[string]any{}
+	redacted := Redact(arg, isSensitive, redactValue, opts...)
+	flattenValue(reflect.ValueOf(&redacted).Elem(), "", result, references{})
+	return result
+}
+
+// flattenValue stores the leaves of v under their paths in result. Pointers,
+// maps and slices already in inProgress are cyclic references and are skipped.
+func flattenValue(v reflect.Value, path string, result map[string]any, inProgress references) {
+	if v.IsValid() && isTextType(v.Type()) && v.CanInterface() {
+		// Text-encodable values such as time.Time or net.IP are leaves
+		result[path] = v.Interface()
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		result[path] = nil
+
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			result[path] = nil
+			return
+		}
+		if _, ok := v.Interface().(error); ok {
+			// Errors are opaque values
+			result[path] = v.Interface()
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			if !inProgress.enter(v) {
+				return
+			}
+			defer inProgress.leave(v)
+		}
+		flattenValue(v.Elem(), path, result, inProgress)
+
+	case reflect.Struct:
+		exported := 0
+		for i := 0; i < v.NumField(); i++ {
+			fieldType := v.Type().Field(i)
+			if !fieldType.IsExported() {
+				continue
+			}
+			exported++
+			flattenValue(v.Field(i), joinPath(path, fieldType.Name), result, inProgress)
+		}
+		if exported == 0 && v.CanInterface() {
+			// Opaque struct, e.g. time.Time
+			result[path] = v.Interface()
+		}
+
+	case reflect.Map:
+		if v.IsNil() || !inProgress.enter(v) {
+			return
+		}
+		defer inProgress.leave(v)
+		for _, key := range v.MapKeys() {
+			flattenValue(v.MapIndex(key), joinPath(path, mapKeyString(key)), result, inProgress)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if !inProgress.enter(v) {
+				return
+			}
+			defer inProgress.leave(v)
+		}
+		for i := 0; i < v.Len(); i++ {
+			flattenValue(v.Index(i), indexPath(path, i), result, inProgress)
+		}
+
+	default:
+		if v.CanInterface() {
+			result[path] = v.Interface()
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactFlatten(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "token" || lower == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Credentials struct {
+		User  string
+		Token string
+	}
+
+	type Service struct {
+		Name   string
+		Cred   Credentials
+		Tags   []string
+		Extra  map[string]any
+		Backup *Credentials
+	}
+
+	service := Service{
+		Name:  "api",
+		Cred:  Credentials{User: "admin", Token: "abc123"},
+		Tags:  []string{"prod", "eu"},
+		Extra: map[string]any{"password": "pw", "port": 8080},
+	}
+
+	result := RedactFlatten(service, isSensitive, redactValue)
+
+	expected := map[string]any{
+		"Name":           "api",
+		"Cred.User":      "admin",
+		"Cred.Token":     "***REDACTED***",
+		"Tags[0]":        "prod",
+		"Tags[1]":        "eu",
+		"Extra.password": "***REDACTED***",
+		"Extra.port":     8080,
+		"Backup":         nil,
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,35 @@
+package yaredact
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+)
+
+// RedactGob decodes gob-encoded data into a value of the same type as
+// template, redacts it like Redact and re-encodes the result. The template
+// only supplies the concrete type, e.g. User{} or &User{}, and isn't modified.
+func RedactGob(data []byte, template any, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) ([]byte, error) {
+	t := reflect.TypeOf(template)
+	if t == nil {
+		return nil, errors.New("yaredact: RedactGob needs a non-nil template")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	decoded := reflect.New(t)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(decoded.Interface()); err != nil {
+		return nil, err
+	}
+
+	w := newWalker(newConfig(isSensitive, redactValue, opts))
+	redacted := w.redactRoot(decoded.Elem())
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(redacted); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package yaredact
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+)
+
+func TestRedactGob(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Account struct {
+		Name     string
+		Password string
+		Tags     []string
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(Account{Name: "alice", Password: "hunter2", Tags: []string{"admin"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, template := range []any{Account{}, &Account{}} {
+			data, err := RedactGob(buf.Bytes(), template, isSensitive, redactValue)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var result Account
+			if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&result); err != nil {
+				t.Fatalf("Expected redacted data to decode, got %v", err)
+			}
+			if result.Password != "***REDACTED***" {
+				t.Errorf("Expected Password to be redacted, got %s", result.Password)
+			}
+			if result.Name != "alice" || len(result.Tags) != 1 || result.Tags[0] != "admin" {
+				t.Errorf("Expected other fields to be kept, got %+v", result)
+			}
+		}
+	})
+
+	t.Run("Invalid Data", func(t *testing.T) {
+		if _, err := RedactGob([]byte("not gob"), Account{}, isSensitive, redactValue); err == nil {
+			t.Errorf("Expected an error for invalid data")
+		}
+		if _, err := RedactGob(buf.Bytes(), nil, isSensitive, redactValue); err == nil {
+			t.Errorf("Expected an error for a nil template")
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package yaredact
+
+// RedactGraphQLVariables returns a redacted copy of the variables of a
+// GraphQL request, as decoded from its JSON body. Keys are checked with
+// isSensitive at every depth, so secrets inside nested input objects and
+// lists of input objects are redacted too. A nil map returns nil.
+func RedactGraphQLVariables(vars map[string]any, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) map[string]any {
+	if vars == nil {
+		return nil
+	}
+	return Redact(vars, isSensitive, redactValue, opts...)
+}
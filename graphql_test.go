@@ -0,0 +1,61 @@
+package yaredact
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactGraphQLVariables(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password" || name == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	var request struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	body := `{
+		"query": "mutation Signup($input: SignupInput!) { signup(input: $input) { id } }",
+		"variables": {
+			"input": {
+				"email": "alice@example.com",
+				"password": "hunter2",
+				"devices": [{"name": "phone", "token": "abc"}],
+				"age": 30
+			}
+		}
+	}`
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result := RedactGraphQLVariables(request.Variables, isSensitive, redactValue)
+
+	expected := map[string]any{
+		"input": map[string]any{
+			"email":    "alice@example.com",
+			"password": "***REDACTED***",
+			"devices":  []any{map[string]any{"name": "phone", "token": "***REDACTED***"}},
+			"age":      float64(30),
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if request.Variables["input"].(map[string]any)["password"] != "hunter2" {
+		t.Errorf("Original was modified")
+	}
+
+	if RedactGraphQLVariables(nil, isSensitive, redactValue) != nil {
+		t.Errorf("Expected nil variables to stay nil")
+	}
+}
@@ -0,0 +1,33 @@
+package yaredact
+
+import "net/textproto"
+
+// RedactMIMEHeader returns a copy of h where every value of a sensitive header
+// is passed through redactValue. Header keys are passed to isSensitive in
+// canonical form (e.g. "Proxy-Authorization"). Values for which redactValue
+// doesn't return a string are kept as-is.
+func RedactMIMEHeader(h textproto.MIMEHeader, isSensitive func(string) bool, redactValue func(any) any) textproto.MIMEHeader {
+	if h == nil {
+		return nil
+	}
+
+	result := make(textproto.MIMEHeader, len(h))
+	for key, values := range h {
+		if values == nil {
+			result[key] = nil
+			continue
+		}
+
+		redacted := make([]string, len(values))
+		copy(redacted, values)
+		if isSensitive(textproto.CanonicalMIMEHeaderKey(key)) {
+			for i, value := range values {
+				if s, ok := redactValue(value).(string); ok {
+					redacted[i] = s
+				}
+			}
+		}
+		result[key] = redacted
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package yaredact
+
+import (
+	"net/textproto"
+	"testing"
+)
+
+func TestRedactMIMEHeader(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "Proxy-Authorization" || name == "Authorization"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	h := textproto.MIMEHeader{}
+	h.Add("Proxy-Authorization", "Basic dXNlcjpwYXNz")
+	h.Add("Content-Type", "text/plain")
+	h["authorization"] = []string{"Bearer a", "Bearer b"} // non-canonical key
+
+	result := RedactMIMEHeader(h, isSensitive, redactValue)
+
+	if got := result.Get("Proxy-Authorization"); got != "***REDACTED***" {
+		t.Errorf("Expected Proxy-Authorization to be redacted, got %s", got)
+	}
+	if got := result.Get("Content-Type"); got != "text/plain" {
+		t.Errorf("Expected Content-Type to be unchanged, got %s", got)
+	}
+	for i, got := range result["authorization"] {
+		if got != "***REDACTED***" {
+			t.Errorf("Expected authorization[%d] to be redacted, got %s", i, got)
+		}
+	}
+	if h.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" {
+		t.Errorf("Original header was modified")
+	}
+
+	if RedactMIMEHeader(nil, isSensitive, redactValue) != nil {
+		t.Errorf("Expected nil header to stay nil")
+	}
+}
@@ -0,0 +1,197 @@
+package yaredact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// RedactJSON redacts an encoded JSON document: object keys are checked with
+// isSensitive like map keys, and the result is re-encoded with object keys
+// in their original order, so redacted documents diff cleanly against the
+// input. Numbers are decoded as json.Number, so those that aren't redacted
+// round-trip exactly. Invalid JSON returns the decoding error.
+func RedactJSON(data []byte, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("yaredact: unexpected data after JSON value")
+	}
+	order, err := readKeyOrder(json.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded bytes.Buffer
+	if err := writeOrdered(&encoded, RedactAny(decoded, isSensitive, redactValue, opts...), order); err != nil {
+		return nil, err
+	}
+	return encoded.Bytes(), nil
+}
+
+// jsonKeyOrder records the order of object keys in a JSON value
+type jsonKeyOrder struct {
+	// keys are the keys of an object in document order, without duplicates
+	keys []string
+	// fields holds the key order of each object member's value
+	fields map[string]*jsonKeyOrder
+	// elements holds the key order of each array element
+	elements []*jsonKeyOrder
+}
+
+// readKeyOrder reads the next JSON value from decoder and returns the order
+// of the object keys in it, or nil for a scalar
+func readKeyOrder(decoder *json.Decoder) (*jsonKeyOrder, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		order := &jsonKeyOrder{fields: map[string]*jsonKeyOrder{}}
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := token.(string)
+			child, err := readKeyOrder(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, seen := order.fields[key]; !seen {
+				order.keys = append(order.keys, key)
+			}
+			// Like json.Unmarshal, the last of duplicate keys wins
+			order.fields[key] = child
+		}
+		_, err := decoder.Token()
+		return order, err
+
+	case json.Delim('['):
+		order := &jsonKeyOrder{}
+		for decoder.More() {
+			child, err := readKeyOrder(decoder)
+			if err != nil {
+				return nil, err
+			}
+			order.elements = append(order.elements, child)
+		}
+		_, err := decoder.Token()
+		return order, err
+	}
+	return nil, nil
+}
+
+// writeOrdered encodes v like json.Marshal, except that the keys of objects
+// recorded in order are written in that order. Keys missing from order,
+// e.g. renamed by WithRedactKeyNames, follow in sorted order.
+func writeOrdered(buf *bytes.Buffer, v any, order *jsonKeyOrder) error {
+	if order == nil {
+		return writeJSON(buf, v)
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for _, key := range order.keys {
+			if _, ok := v[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+		var rest []string
+		for key := range v {
+			if _, ok := order.fields[key]; !ok {
+				rest = append(rest, key)
+			}
+		}
+		sort.Strings(rest)
+		keys = append(keys, rest...)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeOrdered(buf, v[key], order.fields[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			var elemOrder *jsonKeyOrder
+			if i < len(order.elements) {
+				elemOrder = order.elements[i]
+			}
+			if err := writeOrdered(buf, elem, elemOrder); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	return writeJSON(buf, v)
+}
+
+// writeJSON appends the json.Marshal encoding of v to buf
+func writeJSON(buf *bytes.Buffer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// RedactJSONStream reads a JSON document from in, redacts the values of
+// object keys listed in sensitiveNames with "***" and writes the result to
+// out, indented and followed by a newline. It is meant for quick debugging
+// from the command line, e.g. piping a request body through a small tool.
+func RedactJSONStream(in io.Reader, out io.Writer, sensitiveNames []string) error {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(sensitiveNames))
+	for _, name := range sensitiveNames {
+		names[name] = true
+	}
+	isSensitive := func(name string) bool {
+		return names[name]
+	}
+	redactValue := func(any) any {
+		return "***"
+	}
+
+	redacted, err := RedactJSON(data, isSensitive, redactValue)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, redacted, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err = indented.WriteTo(out)
+	return err
+}
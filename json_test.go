@@ -0,0 +1,186 @@
+package yaredact
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	t.Run("Object", func(t *testing.T) {
+		result, err := RedactJSON([]byte(`{"user":"alice","password":"hunter2","items":[{"password":"x"}]}`), isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := `{"user":"alice","password":"***REDACTED***","items":[{"password":"***REDACTED***"}]}`
+		if string(result) != expected {
+			t.Errorf("Expected %s, got %s", expected, result)
+		}
+	})
+
+	t.Run("Key Order", func(t *testing.T) {
+		input := `{"zeta":1,"password":"x","alpha":{"z":true,"a":null,"password":"y"},"list":[{"b":1,"a":2},[{"d":1,"c":2}]],"zeta":3}`
+		result, err := RedactJSON([]byte(input), isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := `{"zeta":3,"password":"***REDACTED***","alpha":{"z":true,"a":null,"password":"***REDACTED***"},"list":[{"b":1,"a":2},[{"d":1,"c":2}]]}`
+		if string(result) != expected {
+			t.Errorf("Expected %s, got %s", expected, result)
+		}
+	})
+
+	t.Run("Renamed Keys", func(t *testing.T) {
+		result, err := RedactJSON([]byte(`{"user":"alice","password":"hunter2","b":1}`), isSensitive, redactValue, WithRedactKeyNames())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := `{"user":"alice","b":1,"***":"***REDACTED***"}`
+		if string(result) != expected {
+			t.Errorf("Expected %s, got %s", expected, result)
+		}
+	})
+
+	t.Run("Number Precision", func(t *testing.T) {
+		input := `{"id":9007199254740993,"password":"x","price":0.10000000000000000555,"big":-18446744073709551615}`
+		result, err := RedactJSON([]byte(input), isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for _, number := range []string{`"id":9007199254740993`, `"price":0.10000000000000000555`, `"big":-18446744073709551615`} {
+			if !strings.Contains(string(result), number) {
+				t.Errorf("Expected %s to round-trip exactly, got %s", number, result)
+			}
+		}
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		for _, input := range []string{`{"password":`, `{"a":1} {"b":2}`, `{"a":1} x`} {
+			if _, err := RedactJSON([]byte(input), isSensitive, redactValue); err == nil {
+				t.Errorf("Expected an error for %s", input)
+			}
+		}
+	})
+}
+
+func TestRedactRawMessage(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password" || strings.ToLower(name) == "credentials"
+	}
+
+	redactValue := func(v any) any {
+		switch v.(type) {
+		case string, json.RawMessage:
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	plugins := map[string]json.RawMessage{
+		"webhook":     json.RawMessage(`{"url":"https://example.com","password":"hunter2","retries":3}`),
+		"credentials": json.RawMessage(`{"user":"admin","token":"abc123"}`),
+		"tags":        json.RawMessage(`["a","b"]`),
+		"broken":      json.RawMessage(`{not json`),
+	}
+
+	result := Redact(plugins, isSensitive, redactValue)
+
+	expected := map[string]string{
+		"webhook":     `{"password":"***REDACTED***","retries":3,"url":"https://example.com"}`,
+		"credentials": `"***REDACTED***"`,
+		"tags":        `["a","b"]`,
+		"broken":      `{not json`,
+	}
+	for key, want := range expected {
+		if got := string(result[key]); got != want {
+			t.Errorf("%s: expected %s, got %s", key, want, got)
+		}
+	}
+	if !strings.Contains(string(plugins["webhook"]), "hunter2") {
+		t.Errorf("Original was modified")
+	}
+
+	t.Run("Sensitive Scalars With String Callback", func(t *testing.T) {
+		stringsOnly := func(v any) any {
+			if _, ok := v.(string); ok {
+				return "***REDACTED***"
+			}
+			return v
+		}
+
+		type Plugin struct {
+			Password    json.RawMessage
+			Credentials json.RawMessage
+			Name        json.RawMessage
+		}
+
+		plugin := Plugin{
+			Password:    json.RawMessage(`"abc"`),
+			Credentials: json.RawMessage(`42`),
+			Name:        json.RawMessage(`"webhook"`),
+		}
+
+		result := Redact(plugin, isSensitive, stringsOnly)
+
+		if string(result.Password) != `"***REDACTED***"` {
+			t.Errorf("Expected string message to be redacted, got %s", result.Password)
+		}
+		if string(result.Credentials) != `42` {
+			t.Errorf("Expected number to be passed through like RedactJSON, got %s", result.Credentials)
+		}
+		if string(result.Name) != `"webhook"` {
+			t.Errorf("Expected non-sensitive message to be kept, got %s", result.Name)
+		}
+
+		encoded, err := RedactJSON([]byte(`{"password":"abc"}`), isSensitive, stringsOnly)
+		if err != nil || !strings.Contains(string(encoded), string(result.Password)) {
+			t.Errorf("Expected RedactJSON to agree, got %s (%v)", encoded, err)
+		}
+	})
+}
+
+func TestRedactJSONStream(t *testing.T) {
+	in := strings.NewReader(`[{"user":"alice","password":"hunter2","pin":1234,"profile":{"token":["a","b"]}}]`)
+	var out strings.Builder
+
+	if err := RedactJSONStream(in, &out, []string{"password", "pin", "token"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `[
+  {
+    "user": "alice",
+    "password": "***",
+    "pin": "***",
+    "profile": {
+      "token": [
+        "***",
+        "***"
+      ]
+    }
+  }
+]
+`
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	if err := RedactJSONStream(strings.NewReader(`{"password":`), &out, nil); err == nil {
+		t.Errorf("Expected an error for invalid JSON")
+	}
+}
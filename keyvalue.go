@@ -0,0 +1,41 @@
+package yaredact
+
+import "reflect"
+
+// WithKeyValueFieldNames treats structs with a field named key and a field
+// named value as the entries of an ordered map, such as
+// []struct{ Key string; Value any }: the value field is sensitive when
+// isSensitive matches the content of the key field. Empty names default to
+// "Key" and "Value".
+func WithKeyValueFieldNames(key, value string) Option {
+	return func(c *config) {
+		if key == "" {
+			key = "Key"
+		}
+		if value == "" {
+			value = "Value"
+		}
+		c.keyFieldName, c.valueFieldName = key, value
+	}
+}
+
+// keyValueIndex returns the index of the value field of the key/value pair
+// struct v when its key is sensitive, or -1
+func (w *walker) keyValueIndex(v reflect.Value) int {
+	if w.keyFieldName == "" {
+		return -1
+	}
+	keyField, ok := v.Type().FieldByName(w.keyFieldName)
+	if !ok || len(keyField.Index) != 1 || !keyField.IsExported() {
+		return -1
+	}
+	valueField, ok := v.Type().FieldByName(w.valueFieldName)
+	if !ok || len(valueField.Index) != 1 {
+		return -1
+	}
+	key := mapKeyString(v.Field(keyField.Index[0]))
+	if key == "" || !w.isSensitive(key) {
+		return -1
+	}
+	return valueField.Index[0]
+}
@@ -0,0 +1,73 @@
+package yaredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyValueFieldNames(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "password" || name == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Pair struct {
+		Key   string
+		Value any
+	}
+
+	type Settings struct {
+		Entries []Pair
+	}
+
+	settings := Settings{Entries: []Pair{
+		{Key: "user", Value: "alice"},
+		{Key: "password", Value: "hunter2"},
+		{Key: "retries", Value: 3},
+	}}
+
+	t.Run("Disabled", func(t *testing.T) {
+		result := Redact(settings, isSensitive, redactValue)
+
+		if !reflect.DeepEqual(result, settings) {
+			t.Errorf("Expected pairs to be kept without the option, got %+v", result)
+		}
+	})
+
+	t.Run("DefaultNames", func(t *testing.T) {
+		result := Redact(settings, isSensitive, redactValue, WithKeyValueFieldNames("", ""))
+
+		expected := Settings{Entries: []Pair{
+			{Key: "user", Value: "alice"},
+			{Key: "password", Value: "***REDACTED***"},
+			{Key: "retries", Value: 3},
+		}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+		if settings.Entries[1].Value != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("CustomNames", func(t *testing.T) {
+		type Header struct {
+			Name string
+			Val  string
+		}
+
+		headers := []Header{{Name: "token", Val: "abc"}, {Name: "accept", Val: "json"}}
+		result := Redact(headers, isSensitive, redactValue, WithKeyValueFieldNames("Name", "Val"))
+
+		expected := []Header{{Name: "token", Val: "***REDACTED***"}, {Name: "accept", Val: "json"}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	})
+}
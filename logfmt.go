@@ -0,0 +1,53 @@
+package yaredact
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RedactToLogfmt redacts arg and renders it as a logfmt line of sorted
+// key=value pairs, with keys flattened like RedactFlatten, e.g.
+// `Cred.Token=*** Name="John Doe" Tags[0]=a`. Values containing spaces,
+// quotes, = or control characters, and empty values, are quoted. Nil values
+// render as nil, and a root value that isn't a container uses the key
+// "value".
+func RedactToLogfmt[T any](arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) string {
+	flattened := RedactFlatten(arg, isSensitive, redactValue, opts...)
+
+	keys := make([]string, 0, len(flattened))
+	for key := range flattened {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		value := flattened[key]
+		if key == "" {
+			key = "value"
+		}
+		b.WriteString(logfmtQuote(key))
+		b.WriteByte('=')
+		if value == nil {
+			b.WriteString("nil")
+		} else {
+			b.WriteString(logfmtQuote(fmt.Sprint(value)))
+		}
+	}
+	return b.String()
+}
+
+// logfmtQuote quotes s if it would otherwise break a logfmt pair
+func logfmtQuote(s string) string {
+	if s == "" || strings.ContainsFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == 0x7f || !strconv.IsPrint(r)
+	}) {
+		return strconv.Quote(s)
+	}
+	return s
+}
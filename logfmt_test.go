@@ -0,0 +1,53 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactToLogfmt(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***"
+		}
+		return v
+	}
+
+	type Credentials struct {
+		User  string
+		Token string
+	}
+
+	type Request struct {
+		Name    string
+		Query   string
+		Note    string
+		Cred    Credentials
+		Tags    []string
+		Retries int
+		Parent  *Request
+	}
+
+	request := Request{
+		Name:    "John Doe",
+		Query:   `a=b "c"`,
+		Cred:    Credentials{User: "john", Token: "abc123"},
+		Tags:    []string{"x"},
+		Retries: 3,
+	}
+
+	got := RedactToLogfmt(request, isSensitive, redactValue)
+
+	expected := `Cred.Token=*** Cred.User=john Name="John Doe" Note="" Parent=nil Query="a=b \"c\"" Retries=3 Tags[0]=x`
+	if got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := RedactToLogfmt("plain text", isSensitive, redactValue); got != `value="plain text"` {
+		t.Errorf("Expected a scalar root to use the value key, got %s", got)
+	}
+}
@@ -0,0 +1,227 @@
+package yaredact
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maskChar is the character used by the built-in masking helpers
+const maskChar = "*"
+
+// MaskLastN returns a redactValue function that masks all but the last n
+// characters of string values, e.g. MaskLastN(4) turns "secret123" into "*****t123".
+// Strings with n or fewer characters are masked entirely, and a negative n is
+// treated as 0. Non-string values pass through.
+func MaskLastN(n int) func(any) any {
+	n = max(n, 0)
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		runes := []rune(s)
+		if len(runes) <= n {
+			return strings.Repeat(maskChar, len(runes))
+		}
+		return strings.Repeat(maskChar, len(runes)-n) + string(runes[len(runes)-n:])
+	}
+}
+
+// MaskFirstN returns a redactValue function that masks all but the first n
+// characters of string values, e.g. MaskFirstN(2) turns "secret" into "se****".
+// Strings with n or fewer characters are masked entirely, and a negative n is
+// treated as 0. Non-string values pass through.
+func MaskFirstN(n int) func(any) any {
+	n = max(n, 0)
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		runes := []rune(s)
+		if len(runes) <= n {
+			return strings.Repeat(maskChar, len(runes))
+		}
+		return string(runes[:n]) + strings.Repeat(maskChar, len(runes)-n)
+	}
+}
+
+// MaskExceptEnds returns a redactValue function that keeps the first and last
+// keep characters of string values and masks the middle, e.g. MaskExceptEnds(2)
+// turns "secret123" into "se*****23". Strings too short to have anything left
+// to mask are masked entirely, and a negative keep is treated as 0. Non-string
+// values pass through.
+func MaskExceptEnds(keep int) func(any) any {
+	keep = max(keep, 0)
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		runes := []rune(s)
+		if len(runes) <= keep*2 {
+			return strings.Repeat(maskChar, len(runes))
+		}
+		return string(runes[:keep]) + strings.Repeat(maskChar, len(runes)-keep*2) + string(runes[len(runes)-keep:])
+	}
+}
+
+// MaskFixed returns a redactValue function that replaces string values with
+// exactly width copies of char, regardless of the original length, so the
+// length of the secret can't be inferred. A negative width is treated as 0.
+// Non-string values pass through.
+func MaskFixed(width int, char rune) func(any) any {
+	mask := strings.Repeat(string(char), max(width, 0))
+	return func(v any) any {
+		if _, ok := v.(string); ok {
+			return mask
+		}
+		return v
+	}
+}
+
+// MaskEmail returns a redactValue function that masks the local part of
+// email addresses but keeps the domain, e.g. "alice@example.com" becomes
+// "***@example.com". Strings without an @ are masked entirely. Non-string
+// values pass through.
+func MaskEmail() func(any) any {
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		at := strings.LastIndex(s, "@")
+		if at < 0 {
+			return strings.Repeat(maskChar, len([]rune(s)))
+		}
+		return strings.Repeat(maskChar, 3) + s[at:]
+	}
+}
+
+// MaskCreditCard returns a redactValue function that masks all but the last
+// four digits of card numbers, keeping spaces and dashes, e.g.
+// "4111 1111 1111 1111" becomes "**** **** **** 1111". Only strings of 12 to
+// 19 digits, optionally separated by spaces or dashes, that pass the Luhn
+// check are masked; other strings and non-string values pass through.
+func MaskCreditCard() func(any) any {
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok || !isCardNumber(s) {
+			return v
+		}
+		masked := []byte(s)
+		digits := 0
+		for i := len(masked) - 1; i >= 0; i-- {
+			if masked[i] < '0' || masked[i] > '9' {
+				continue
+			}
+			if digits++; digits > 4 {
+				masked[i] = maskChar[0]
+			}
+		}
+		return string(masked)
+	}
+}
+
+// isCardNumber reports whether s is a card number: 12 to 19 digits,
+// optionally separated by spaces or dashes, passing the Luhn check
+func isCardNumber(s string) bool {
+	digits, sum := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 12 && digits <= 19 && sum%10 == 0
+}
+
+// MaskDigits returns a redactValue function that replaces integer and float
+// values with a string of the same shape, every digit masked, e.g. the PIN
+// 1234 becomes "****" and -12.5 becomes "-**.*", so the digit count stays
+// visible. Since the result is a string, it only applies to fields that can
+// hold one, such as any fields or map[string]any values; other fields fall
+// back to being recursed into, which leaves numbers unchanged. Other values
+// pass through.
+func MaskDigits() func(any) any {
+	return func(v any) any {
+		var formatted string
+		switch n := reflect.ValueOf(v); n.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			formatted = strconv.FormatInt(n.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			formatted = strconv.FormatUint(n.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			formatted = strconv.FormatFloat(n.Float(), 'f', -1, n.Type().Bits())
+		default:
+			return v
+		}
+		masked := []byte(formatted)
+		for i, c := range masked {
+			if c >= '0' && c <= '9' {
+				masked[i] = maskChar[0]
+			}
+		}
+		return string(masked)
+	}
+}
+
+// truncatedSuffix marks strings shortened by TruncateRedactor
+const truncatedSuffix = "…(truncated)"
+
+// TruncateRedactor returns a redactValue function that shortens string values
+// longer than max characters to their first max characters followed by
+// "…(truncated)". Shorter strings and non-string values pass through, so to
+// also hide secrets, apply it to the result of a mask. A negative max is
+// treated as 0.
+func TruncateRedactor(max int) func(any) any {
+	if max < 0 {
+		max = 0
+	}
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		runes := []rune(s)
+		if len(runes) <= max {
+			return s
+		}
+		return string(runes[:max]) + truncatedSuffix
+	}
+}
+
+// StringRedactor adapts a func(string) string into a redactValue function,
+// applying it to string values and passing other values through.
+func StringRedactor(redact func(string) string) func(any) any {
+	return func(v any) any {
+		if s, ok := v.(string); ok {
+			return redact(s)
+		}
+		return v
+	}
+}
+
+// BytesRedactor adapts a func([]byte) []byte into a redactValue function,
+// applying it to []byte values and passing other values through. The
+// function receives a copy, so it may modify the bytes in place.
+func BytesRedactor(redact func([]byte) []byte) func(any) any {
+	return func(v any) any {
+		if b, ok := v.([]byte); ok {
+			return redact(append([]byte(nil), b...))
+		}
+		return v
+	}
+}
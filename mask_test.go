@@ -0,0 +1,255 @@
+package yaredact
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestMaskHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		redact   func(any) any
+		input    any
+		expected any
+	}{
+		{"MaskLastN", MaskLastN(4), "secret123", "*****t123"},
+		{"MaskLastN Exact Window", MaskLastN(4), "abcd", "****"},
+		{"MaskLastN Short String", MaskLastN(4), "ab", "**"},
+		{"MaskLastN Empty String", MaskLastN(4), "", ""},
+		{"MaskLastN Multibyte", MaskLastN(1), "日本語", "**語"},
+		{"MaskLastN Non-String", MaskLastN(4), 12345, 12345},
+		{"MaskFirstN", MaskFirstN(2), "secret", "se****"},
+		{"MaskFirstN Short String", MaskFirstN(4), "abc", "***"},
+		{"MaskFirstN Non-String", MaskFirstN(2), true, true},
+		{"MaskExceptEnds", MaskExceptEnds(2), "secret123", "se*****23"},
+		{"MaskExceptEnds Short String", MaskExceptEnds(2), "abcd", "****"},
+		{"MaskExceptEnds Single Middle", MaskExceptEnds(2), "abcde", "ab*de"},
+		{"MaskExceptEnds Non-String", MaskExceptEnds(2), 1.5, 1.5},
+		{"MaskLastN Negative", MaskLastN(-1), "abc", "***"},
+		{"MaskFirstN Negative", MaskFirstN(-1), "abc", "***"},
+		{"MaskExceptEnds Negative", MaskExceptEnds(-1), "abc", "***"},
+		{"MaskFixed Negative", MaskFixed(-1, '*'), "abc", ""},
+		{"TruncateRedactor Negative", TruncateRedactor(-1), "abc", truncatedSuffix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.redact(tt.input); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMaskFixed(t *testing.T) {
+	redact := MaskFixed(8, '#')
+
+	for _, input := range []string{"", "a", "secret123", "a much longer secret value than the mask width"} {
+		t.Run(input, func(t *testing.T) {
+			if got := redact(input); got != "########" {
+				t.Errorf("Expected fixed-width mask, got %v", got)
+			}
+		})
+	}
+
+	t.Run("Non-String", func(t *testing.T) {
+		if got := redact(42); got != 42 {
+			t.Errorf("Expected non-string to pass through, got %v", got)
+		}
+	})
+}
+
+func TestRedactorAdapters(t *testing.T) {
+	t.Run("StringRedactor", func(t *testing.T) {
+		redactValue := StringRedactor(strings.ToUpper)
+
+		if got := redactValue("secret"); got != "SECRET" {
+			t.Errorf("Expected SECRET, got %v", got)
+		}
+		if got := redactValue(42); got != 42 {
+			t.Errorf("Expected non-string to pass through, got %v", got)
+		}
+
+		type User struct {
+			Password string
+		}
+		result := Redact(User{Password: "abc"}, func(name string) bool { return name == "Password" }, redactValue)
+		if result.Password != "ABC" {
+			t.Errorf("Expected Password to be redacted, got %s", result.Password)
+		}
+	})
+
+	t.Run("BytesRedactor", func(t *testing.T) {
+		redactValue := BytesRedactor(func(b []byte) []byte {
+			for i := range b {
+				b[i] = '*'
+			}
+			return b
+		})
+
+		original := []byte("key")
+		got, ok := redactValue(original).([]byte)
+		if !ok || string(got) != "***" {
+			t.Errorf("Expected ***, got %v", got)
+		}
+		if string(original) != "key" {
+			t.Errorf("Original bytes were modified")
+		}
+		if got := redactValue("key"); got != "key" {
+			t.Errorf("Expected non-bytes to pass through, got %v", got)
+		}
+	})
+}
+
+func TestMaskEmail(t *testing.T) {
+	redactValue := MaskEmail()
+
+	tests := map[string]string{
+		"alice@example.com":   "***@example.com",
+		`"a@b"@example.com`:   "***@example.com",
+		"x@sub.example.co.uk": "***@sub.example.co.uk",
+		"not-an-email":        "************",
+		"":                    "",
+	}
+	for input, expected := range tests {
+		if got := redactValue(input); got != expected {
+			t.Errorf("MaskEmail(%q): expected %q, got %v", input, expected, got)
+		}
+	}
+	if got := redactValue(42); got != 42 {
+		t.Errorf("Expected non-string to pass through, got %v", got)
+	}
+
+	t.Run("mail.Address", func(t *testing.T) {
+		type Message struct {
+			From mail.Address
+			To   []*mail.Address
+		}
+
+		isSensitive := func(name string) bool {
+			return name == "Address"
+		}
+
+		msg := Message{
+			From: mail.Address{Name: "Alice", Address: "alice@example.com"},
+			To:   []*mail.Address{{Name: "Bob", Address: "bob@example.org"}, nil},
+		}
+
+		result := Redact(msg, isSensitive, redactValue)
+
+		if result.From.Address != "***@example.com" || result.From.Name != "Alice" {
+			t.Errorf("Expected From address to be masked and name kept, got %+v", result.From)
+		}
+		if result.To[0].Address != "***@example.org" || result.To[0].Name != "Bob" {
+			t.Errorf("Expected To address to be masked and name kept, got %+v", result.To[0])
+		}
+		if result.To[1] != nil {
+			t.Errorf("Expected nil recipient to stay nil")
+		}
+		if msg.From.Address != "alice@example.com" {
+			t.Errorf("Original was modified")
+		}
+	})
+}
+
+func TestTruncateRedactor(t *testing.T) {
+	redactValue := TruncateRedactor(5)
+
+	tests := map[string]string{
+		"":            "",
+		"abc":         "abc",
+		"abcde":       "abcde",
+		"abcdef":      "abcde…(truncated)",
+		"héllo wörld": "héllo…(truncated)",
+	}
+	for input, expected := range tests {
+		if got := redactValue(input); got != expected {
+			t.Errorf("TruncateRedactor(5)(%q): expected %q, got %v", input, expected, got)
+		}
+	}
+	if got := redactValue(42); got != 42 {
+		t.Errorf("Expected non-string to pass through, got %v", got)
+	}
+
+	t.Run("AfterMask", func(t *testing.T) {
+		mask := MaskLastN(2)
+		redactValue := func(v any) any {
+			return TruncateRedactor(4)(mask(v))
+		}
+		if got := redactValue("secret123"); got != "****…(truncated)" {
+			t.Errorf("Expected masked and truncated value, got %v", got)
+		}
+	})
+}
+
+func TestMaskCreditCard(t *testing.T) {
+	redactValue := MaskCreditCard()
+
+	tests := map[string]string{
+		"4111111111111111":       "************1111",
+		"4111 1111 1111 1111":    "**** **** **** 1111",
+		"5500-0000-0000-0004":    "****-****-****-0004",
+		"378282246310005":        "***********0005",
+		"4111111111111112":       "4111111111111112",
+		"order 4111111111111111": "order 4111111111111111",
+		"1234":                   "1234",
+		"hello":                  "hello",
+		"":                       "",
+	}
+	for input, expected := range tests {
+		if got := redactValue(input); got != expected {
+			t.Errorf("MaskCreditCard(%q): expected %q, got %v", input, expected, got)
+		}
+	}
+	if got := redactValue(4111111111111111); got != 4111111111111111 {
+		t.Errorf("Expected non-string to pass through, got %v", got)
+	}
+}
+
+func TestMaskDigits(t *testing.T) {
+	redactValue := MaskDigits()
+
+	tests := []struct {
+		input    any
+		expected any
+	}{
+		{1234, "****"},
+		{int64(-42), "-**"},
+		{uint8(7), "*"},
+		{12.5, "**.*"},
+		{float32(0.25), "*.**"},
+		{"1234", "1234"},
+		{true, true},
+	}
+	for _, tt := range tests {
+		if got := redactValue(tt.input); got != tt.expected {
+			t.Errorf("MaskDigits(%#v): expected %#v, got %#v", tt.input, tt.expected, got)
+		}
+	}
+
+	t.Run("AnyField", func(t *testing.T) {
+		type Card struct {
+			Holder string
+			PIN    any
+			CVV    int
+		}
+
+		isSensitive := func(name string) bool {
+			return name == "PIN" || name == "CVV"
+		}
+
+		result := Redact(Card{Holder: "alice", PIN: 1234, CVV: 567}, isSensitive, redactValue)
+
+		if result.PIN != "****" {
+			t.Errorf("Expected PIN to be masked with its digit count, got %#v", result.PIN)
+		}
+		if result.Holder != "alice" {
+			t.Errorf("Expected Holder to be kept, got %s", result.Holder)
+		}
+		// An int field can't hold the mask
+		if result.CVV != 567 {
+			t.Errorf("Expected int field to be left as-is, got %d", result.CVV)
+		}
+	})
+}
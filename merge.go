@@ -0,0 +1,117 @@
+package yaredact
+
+import "reflect"
+
+// MergeUnredacted returns a copy of patch in which every string equal to
+// placeholder is replaced by the value at the same path in base, so a form
+// or document that was redacted for display can be written back without
+// overwriting the real secrets with the placeholder. Struct fields, map
+// entries, slice and array elements, pointers and interfaces are merged
+// recursively; values with no counterpart in base are kept from patch.
+// Cyclic pointers, maps and slices in patch are merged once and point back
+// into the merged copy.
+func MergeUnredacted[T any](base, patch T, placeholder string) T {
+	merged := mergeValue(reflect.ValueOf(&base).Elem(), reflect.ValueOf(&patch).Elem(), placeholder, map[reference]reflect.Value{})
+	return merged.Interface().(T)
+}
+
+// mergeValue merges patch over base as described by MergeUnredacted.
+// inProgress maps the pointers and maps of patch being merged to their
+// merged copies.
+func mergeValue(base, patch reflect.Value, placeholder string, inProgress map[reference]reflect.Value) reflect.Value {
+	if !base.IsValid() || !patch.IsValid() {
+		return patch
+	}
+	if patch.Kind() == reflect.String && patch.String() == placeholder && base.Type() == patch.Type() {
+		return base
+	}
+
+	switch patch.Kind() {
+	case reflect.Interface:
+		if patch.IsNil() || base.IsNil() {
+			return patch
+		}
+		// A placeholder may stand in for a value of any type, e.g. in a map[string]any
+		if elem := patch.Elem(); elem.Kind() == reflect.String && elem.String() == placeholder {
+			return base
+		}
+		if base.Elem().Type() != patch.Elem().Type() {
+			return patch
+		}
+		result := reflect.New(patch.Type()).Elem()
+		result.Set(mergeValue(base.Elem(), patch.Elem(), placeholder, inProgress))
+		return result
+
+	case reflect.Ptr:
+		if patch.IsNil() || base.IsNil() {
+			return patch
+		}
+		ref := referenceOf(patch)
+		if merged, ok := inProgress[ref]; ok {
+			return merged
+		}
+		result := reflect.New(patch.Type().Elem())
+		inProgress[ref] = result
+		defer delete(inProgress, ref)
+		result.Elem().Set(mergeValue(base.Elem(), patch.Elem(), placeholder, inProgress))
+		return result
+
+	case reflect.Struct:
+		result := reflect.New(patch.Type()).Elem()
+		result.Set(patch)
+		for i := 0; i < patch.NumField(); i++ {
+			if patch.Type().Field(i).IsExported() {
+				result.Field(i).Set(mergeValue(base.Field(i), patch.Field(i), placeholder, inProgress))
+			}
+		}
+		return result
+
+	case reflect.Map:
+		if patch.IsNil() || base.IsNil() {
+			return patch
+		}
+		ref := referenceOf(patch)
+		if merged, ok := inProgress[ref]; ok {
+			return merged
+		}
+		result := reflect.MakeMapWithSize(patch.Type(), patch.Len())
+		inProgress[ref] = result
+		defer delete(inProgress, ref)
+		for _, key := range patch.MapKeys() {
+			result.SetMapIndex(key, mergeValue(base.MapIndex(key), patch.MapIndex(key), placeholder, inProgress))
+		}
+		return result
+
+	case reflect.Slice:
+		if patch.IsNil() {
+			return patch
+		}
+		ref := referenceOf(patch)
+		if merged, ok := inProgress[ref]; ok {
+			return merged
+		}
+		result := reflect.MakeSlice(patch.Type(), patch.Len(), patch.Cap())
+		inProgress[ref] = result
+		defer delete(inProgress, ref)
+		mergeElements(base, patch, result, placeholder, inProgress)
+		return result
+
+	case reflect.Array:
+		result := reflect.New(patch.Type()).Elem()
+		mergeElements(base, patch, result, placeholder, inProgress)
+		return result
+	}
+	return patch
+}
+
+// mergeElements merges the elements of patch over those of base at the same
+// index into result
+func mergeElements(base, patch, result reflect.Value, placeholder string, inProgress map[reference]reflect.Value) {
+	for i := 0; i < patch.Len(); i++ {
+		if i < base.Len() {
+			result.Index(i).Set(mergeValue(base.Index(i), patch.Index(i), placeholder, inProgress))
+		} else {
+			result.Index(i).Set(patch.Index(i))
+		}
+	}
+}
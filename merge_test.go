@@ -0,0 +1,91 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeUnredacted(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token" || lower == "pin"
+	}
+
+	redactValue := func(v any) any {
+		return "***"
+	}
+
+	type Credentials struct {
+		User     string
+		Password string
+	}
+
+	type Settings struct {
+		Name     string
+		Password string
+		Primary  *Credentials
+		Replicas []Credentials
+		Extra    map[string]any
+	}
+
+	stored := Settings{
+		Name:     "db",
+		Password: "hunter2",
+		Primary:  &Credentials{User: "admin", Password: "p1"},
+		Replicas: []Credentials{{User: "ro", Password: "p2"}},
+		Extra:    map[string]any{"token": "abc", "pin": 1234, "region": "us"},
+	}
+
+	// The redacted form is edited and submitted back
+	form := Redact(stored, isSensitive, redactValue)
+	form.Name = "db-renamed"
+	form.Primary.User = "root"
+	form.Replicas = append(form.Replicas, Credentials{User: "new", Password: "p3"})
+	form.Extra["region"] = "eu"
+
+	merged := MergeUnredacted(stored, form, "***")
+
+	expected := Settings{
+		Name:     "db-renamed",
+		Password: "hunter2",
+		Primary:  &Credentials{User: "root", Password: "p1"},
+		Replicas: []Credentials{{User: "ro", Password: "p2"}, {User: "new", Password: "p3"}},
+		Extra:    map[string]any{"token": "abc", "pin": 1234, "region": "eu"},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, merged)
+	}
+
+	t.Run("ChangedSecret", func(t *testing.T) {
+		form := Redact(stored, isSensitive, redactValue)
+		form.Password = "new-password"
+
+		merged := MergeUnredacted(stored, form, "***")
+
+		if merged.Password != "new-password" {
+			t.Errorf("Expected a changed secret to be written back, got %s", merged.Password)
+		}
+	})
+
+	t.Run("NoBase", func(t *testing.T) {
+		merged := MergeUnredacted(Settings{}, Settings{Password: "***"}, "***")
+
+		if merged.Password != "" {
+			t.Errorf("Expected a placeholder without a stored value to become the zero value, got %s", merged.Password)
+		}
+	})
+	t.Run("SliceCapacity", func(t *testing.T) {
+		replicas := make([]Credentials, 1, 4)
+		replicas[0] = Credentials{User: "ro", Password: "***"}
+
+		merged := MergeUnredacted(stored, Settings{Replicas: replicas}, "***")
+
+		if len(merged.Replicas) != 1 || cap(merged.Replicas) != 4 {
+			t.Errorf("Expected length 1 and capacity 4, got %d and %d", len(merged.Replicas), cap(merged.Replicas))
+		}
+		if merged.Replicas[0].Password != stored.Replicas[0].Password {
+			t.Errorf("Expected placeholder to be restored, got %s", merged.Replicas[0].Password)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package yaredact
+
+import "reflect"
+
+// Metrics receives a count of redacted values, e.g. to export counters of
+// how many strings or numbers each request had redacted. Implementations
+// must be safe for concurrent use when WithParallelism is used.
+type Metrics interface {
+	// IncRedacted is called once for each redacted value with its kind
+	IncRedacted(kind reflect.Kind)
+}
+
+// WithMetrics reports every redacted value to m
+func WithMetrics(m Metrics) Option {
+	return func(c *config) {
+		c.metrics = m
+	}
+}
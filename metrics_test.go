@@ -0,0 +1,73 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeMetrics struct {
+	mu     sync.Mutex
+	counts map[reflect.Kind]int
+}
+
+func (m *fakeMetrics) IncRedacted(kind reflect.Kind) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[kind]++
+}
+
+func TestWithMetrics(t *testing.T) {
+	isSensitive := func(name string) bool {
+		name = strings.ToLower(name)
+		return name == "password" || name == "token" || name == "pin"
+	}
+
+	redactValue := func(v any) any {
+		switch v.(type) {
+		case string:
+			return "***REDACTED***"
+		case int:
+			return 0
+		}
+		return v
+	}
+
+	type Account struct {
+		Name     string
+		Password string
+		PIN      int
+		Extra    map[string]any
+	}
+
+	account := Account{
+		Name:     "alice",
+		Password: "secret",
+		PIN:      1234,
+		Extra:    map[string]any{"token": "abc", "region": "us"},
+	}
+
+	t.Run("CountsRedactedValuesByKind", func(t *testing.T) {
+		metrics := &fakeMetrics{counts: map[reflect.Kind]int{}}
+		Redact(account, isSensitive, redactValue, WithMetrics(metrics))
+
+		expected := map[reflect.Kind]int{reflect.String: 1, reflect.Int: 1, reflect.Interface: 1}
+		if !reflect.DeepEqual(metrics.counts, expected) {
+			t.Errorf("Expected counts %v, got %v", expected, metrics.counts)
+		}
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		metrics := &fakeMetrics{counts: map[reflect.Kind]int{}}
+		accounts := make([]Account, parallelThreshold)
+		for i := range accounts {
+			accounts[i] = Account{Password: "secret"}
+		}
+		Redact(accounts, isSensitive, redactValue, WithMetrics(metrics), WithParallelism(4))
+
+		if metrics.counts[reflect.String] != parallelThreshold {
+			t.Errorf("Expected %d strings redacted, got %d", parallelThreshold, metrics.counts[reflect.String])
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package yaredact
+
+import "sync"
+
+// WithRedactOncePerName redacts only the first sensitive field or map key of
+// each name within a call, copying later occurrences as-is, e.g. for sampled
+// audit logs that show a field is redacted without repeating it. Which
+// occurrence comes first follows traversal order, which is random for map
+// entries and concurrent for elements with WithParallelism.
+func WithRedactOncePerName() Option {
+	return func(c *config) {
+		c.redactOncePerName = true
+	}
+}
+
+// nameSet records the names already redacted in a call with
+// WithRedactOncePerName, shared by concurrent child walkers
+type nameSet struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+// firstRedaction reports whether name hasn't been redacted yet in this call,
+// marking it as redacted. It always reports true without
+// WithRedactOncePerName.
+func (w *walker) firstRedaction(name string) bool {
+	if w.redactedNames == nil {
+		return true
+	}
+	w.redactedNames.mu.Lock()
+	defer w.redactedNames.mu.Unlock()
+	if w.redactedNames.names[name] {
+		return false
+	}
+	w.redactedNames.names[name] = true
+	return true
+}
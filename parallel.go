@@ -0,0 +1,119 @@
+package yaredact
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// parallelThreshold is the minimum number of elements for a slice or array
+// to be redacted in parallel when WithParallelism is used
+const parallelThreshold = 256
+
+// WithParallelism redacts the elements of large slices and arrays using up to
+// n goroutines. Element order is preserved. The isSensitive and redactValue
+// callbacks (and any other callbacks) must be safe for concurrent use.
+// Values of n below 2 disable parallelism.
+func WithParallelism(n int) Option {
+	return func(c *config) {
+		c.parallelism = n
+	}
+}
+
+// copiesElements reports whether the elements of a slice or array of elem
+// can be copied wholesale: they are numbers, strings or bools that no
+// configured option inspects one by one, e.g. the bytes of a []byte
+func (w *walker) copiesElements(elem reflect.Type) bool {
+	if !isBasicKind(elem.Kind()) {
+		return false
+	}
+	if elem.Kind() == reflect.String && (w.isSensitiveValue != nil || len(w.scrubPatterns) > 0) {
+		return false
+	}
+	return len(w.paths) == 0 && w.typeHandlers[elem] == nil && w.isSensitiveType == nil &&
+		!w.inSensitiveSubtree && w.isSensitiveIndex == nil && w.nodes == nil
+}
+
+// redactElements redacts each element of the slice or array v into result
+func (w *walker) redactElements(v, result reflect.Value, path string) {
+	if w.parallelism > 1 && v.Len() >= parallelThreshold {
+		w.redactElementsParallel(v, result, path)
+		return
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		w.redactElement(v, result, path, i)
+	}
+}
+
+// redactElement redacts element i of the slice or array v at path into result
+func (w *walker) redactElement(v, result reflect.Value, path string, i int) {
+	elem := v.Index(i)
+	elemPath := w.indexPath(path, i)
+	if w.isSensitiveIndex != nil && elem.CanInterface() && w.isSensitiveIndex(path, i) {
+		if redacted, ok := w.redactSensitive(elem, elemPath); ok {
+			w.record(elemPath, "", elem.Kind())
+			result.Index(i).Set(zeroIfDropped(redacted, elem.Type()))
+			return
+		}
+	}
+	w.redactInto(result.Index(i), elem, elemPath)
+}
+
+// redactElementsParallel splits the elements into contiguous chunks, each
+// redacted by its own goroutine with a child walker. Child records are merged
+// in chunk order so reports match a sequential traversal, and the first
+// child error is kept.
+func (w *walker) redactElementsParallel(v, result reflect.Value, path string) {
+	workers := w.parallelism
+	chunkSize := (v.Len() + workers - 1) / workers
+	children := make([]*walker, 0, workers)
+
+	var wg sync.WaitGroup
+	for start := 0; start < v.Len(); start += chunkSize {
+		end := min(start+chunkSize, v.Len())
+		child := w.child()
+		children = append(children, child)
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				child.redactElement(v, result, path, i)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	for _, child := range children {
+		w.records = append(w.records, child.records...)
+		if w.err == nil {
+			w.err = child.err
+		}
+	}
+}
+
+// child returns a walker for a concurrent sub-traversal starting at the
+// current depth, subtree state and pointers being copied
+func (w *walker) child() *walker {
+	var inProgress map[reference]reflect.Value
+	if len(w.inProgress) > 0 {
+		inProgress = make(map[reference]reflect.Value, len(w.inProgress))
+		for ref, copied := range w.inProgress {
+			inProgress[ref] = copied
+		}
+	}
+	return &walker{
+		config:             w.config,
+		report:             w.report,
+		depth:              w.depth,
+		inSensitiveSubtree: w.inSensitiveSubtree,
+		strict:             w.strict,
+		nodes:              w.nodes,
+		redactedNames:      w.redactedNames,
+		redactions:         w.redactions,
+		piiRedactor:        w.piiRedactor,
+		inProgress:         inProgress,
+		pathAliases:        slices.Clone(w.pathAliases),
+	}
+}
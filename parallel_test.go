@@ -0,0 +1,91 @@
+package yaredact
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type parallelRecord struct {
+	ID       int
+	Name     string
+	Password string
+	Tags     []string
+	Meta     map[string]string
+}
+
+func makeParallelRecords(n int) []parallelRecord {
+	records := make([]parallelRecord, n)
+	for i := range records {
+		records[i] = parallelRecord{
+			ID:       i,
+			Name:     fmt.Sprintf("user-%d", i),
+			Password: fmt.Sprintf("secret-%d", i),
+			Tags:     []string{"a", "b", "c"},
+			Meta:     map[string]string{"token": "tok", "region": "eu"},
+		}
+	}
+	return records
+}
+
+func parallelIsSensitive(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "password" || lower == "token"
+}
+
+func parallelRedactValue(v any) any {
+	if _, ok := v.(string); ok {
+		return "***REDACTED***"
+	}
+	return v
+}
+
+func TestParallelism(t *testing.T) {
+	records := makeParallelRecords(1000)
+
+	sequential, sequentialReport := RedactWithReport(records, parallelIsSensitive, parallelRedactValue)
+	parallel, parallelReport := RedactWithReport(records, parallelIsSensitive, parallelRedactValue, WithParallelism(4))
+
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Expected parallel result to match sequential result")
+	}
+	if !reflect.DeepEqual(sequentialReport, parallelReport) {
+		t.Errorf("Expected parallel report to match sequential report order")
+	}
+	for i, record := range parallel {
+		if record.ID != i {
+			t.Fatalf("Expected order to be preserved, got ID %d at index %d", record.ID, i)
+		}
+		if record.Password != "***REDACTED***" || record.Meta["token"] != "***REDACTED***" {
+			t.Fatalf("Expected record %d to be redacted, got %+v", i, record)
+		}
+	}
+
+	t.Run("Array", func(t *testing.T) {
+		var array [parallelThreshold]parallelRecord
+		copy(array[:], records)
+
+		result := Redact(array, parallelIsSensitive, parallelRedactValue, WithParallelism(3))
+
+		if result[parallelThreshold-1].Password != "***REDACTED***" || result[0].Name != "user-0" {
+			t.Errorf("Expected array elements to be redacted in parallel")
+		}
+	})
+}
+
+func BenchmarkRedactLargeSlice(b *testing.B) {
+	records := makeParallelRecords(10000)
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Redact(records, parallelIsSensitive, parallelRedactValue)
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Redact(records, parallelIsSensitive, parallelRedactValue, WithParallelism(8))
+		}
+	})
+}
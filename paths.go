@@ -0,0 +1,258 @@
+package yaredact
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a compiled path: a field name or map key, a
+// specific index, or any index
+type pathSegment struct {
+	name     string
+	index    int
+	wildcard bool
+	isIndex  bool
+}
+
+// pathPattern is a compiled path expression such as $.items[*].secret
+type pathPattern []pathSegment
+
+// RedactPaths redacts only the values addressed by the given path expressions,
+// passing each of them to redactValue. Paths use a small JSONPath subset:
+//
+//	$.user.credentials.token   field names or map keys separated by dots
+//	$.items[0].secret          a specific slice or array index
+//	$.items[*].secret          every slice or array element, also $.items[].secret
+//
+// Names are matched against Go struct field names and map keys, as they
+// appear in RedactionRecord.Path, and against the struct tag names checked
+// by Redact, e.g. "user" for a field tagged json:"user". With
+// WithNormalizeName, names are also compared after normalizing both sides.
+// Invalid paths never match; use ValidatePath to check externally configured
+// paths up front.
+func RedactPaths[T any](arg T, paths []string, redactValue func(any) any, opts ...Option) T {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero
+	}
+
+	c := newConfig(func(string) bool { return false }, redactValue, opts)
+	for _, path := range paths {
+		if pattern, err := compilePath(path); err == nil {
+			c.paths = append(c.paths, pattern)
+		}
+	}
+	w := newWalker(c)
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	return result.(T)
+}
+
+// RedactFieldIndices redacts struct fields by their zero-based declaration
+// index rather than by name, for positional or generated record types whose
+// field names carry no meaning. The indices apply to every struct in arg;
+// indices outside a struct's fields are ignored.
+func RedactFieldIndices[T any](arg T, indices []int, redactValue func(any) any, opts ...Option) T {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero
+	}
+
+	c := newConfig(func(string) bool { return false }, redactValue, opts)
+	c.fieldIndices = map[int]bool{}
+	for _, i := range indices {
+		c.fieldIndices[i] = true
+	}
+	w := newWalker(c)
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	return result.(T)
+}
+
+// RedactByValue redacts every string in arg, whether a field, map value,
+// slice element or arg itself, for which isSensitiveValue reports true,
+// regardless of names. It is meant for scanning arbitrary payloads for
+// secret-shaped strings such as tokens or card numbers.
+func RedactByValue[T any](arg T, isSensitiveValue func(string) bool, redactValue func(any) any, opts ...Option) T {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero
+	}
+
+	c := newConfig(func(string) bool { return false }, redactValue, opts)
+	c.isSensitiveValue = isSensitiveValue
+	w := newWalker(c)
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	return result.(T)
+}
+
+// ValidatePath reports whether path is a valid expression for RedactPaths
+func ValidatePath(path string) error {
+	_, err := compilePath(path)
+	return err
+}
+
+// compilePath parses a path expression into its segments
+func compilePath(path string) (pathPattern, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.New("yaredact: path must start with $: " + strconv.Quote(path))
+	}
+
+	var pattern pathPattern
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, errors.New("yaredact: empty name in path " + strconv.Quote(path))
+			}
+			pattern = append(pattern, pathSegment{name: name})
+			rest = rest[end+1:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, errors.New("yaredact: unterminated [ in path " + strconv.Quote(path))
+			}
+			inner := rest[1:end]
+			if inner == "*" || inner == "" {
+				pattern = append(pattern, pathSegment{isIndex: true, wildcard: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, errors.New("yaredact: invalid index " + strconv.Quote(inner) + " in path " + strconv.Quote(path))
+				}
+				pattern = append(pattern, pathSegment{isIndex: true, index: index})
+			}
+			rest = rest[end+1:]
+
+		default:
+			return nil, errors.New("yaredact: unexpected " + strconv.Quote(rest[:1]) + " in path " + strconv.Quote(path))
+		}
+	}
+	return pattern, nil
+}
+
+// pathAlias holds the tag names of a struct field whose name ends at offset
+// end of the walker path
+type pathAlias struct {
+	end   int
+	names []string
+}
+
+// fieldTagNames returns the names a struct field carries in the configured
+// tags, e.g. "token" for json:"token,omitempty"
+func (c *config) fieldTagNames(field reflect.StructField) []string {
+	var names []string
+	for _, tagName := range c.tagNames {
+		if tagValue := field.Tag.Get(tagName); tagValue != "" {
+			for _, name := range tagNamesOf(tagValue) {
+				if name != "" && name != "-" {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// matches reports whether the walker path, as built by joinPath and
+// indexPath, is addressed by the pattern. A name segment matches a name in
+// the path literally, through the tag names in aliases, or after normalize
+// when it isn't nil.
+func (p pathPattern) matches(path string, aliases []pathAlias, normalize func(string) string) bool {
+	rest := path
+	for i, seg := range p {
+		if seg.isIndex {
+			end := strings.IndexByte(rest, ']')
+			if !strings.HasPrefix(rest, "[") || end < 0 {
+				return false
+			}
+			if inner := rest[1:end]; inner == "" {
+				// A collapsed index only matches a wildcard
+				if !seg.wildcard {
+					return false
+				}
+			} else if index, err := strconv.Atoi(inner); err != nil || (!seg.wildcard && index != seg.index) {
+				return false
+			}
+			rest = rest[end+1:]
+			continue
+		}
+
+		if i > 0 {
+			if !strings.HasPrefix(rest, ".") {
+				return false
+			}
+			rest = rest[1:]
+		}
+		if strings.HasPrefix(rest, seg.name) && endsName(rest[len(seg.name):]) {
+			rest = rest[len(seg.name):]
+			continue
+		}
+
+		// Otherwise compare the next name in the path by its aliases
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if !nameMatches(seg.name, rest[:end], aliasesAt(aliases, len(path)-len(rest)+end), normalize) {
+			return false
+		}
+		rest = rest[end:]
+	}
+	return rest == ""
+}
+
+// endsName reports whether rest starts where a name in a path ends
+func endsName(rest string) bool {
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+// aliasesAt returns the tag names of the struct field whose name ends at
+// offset end of the path
+func aliasesAt(aliases []pathAlias, end int) []string {
+	for _, alias := range aliases {
+		if alias.end == end {
+			return alias.names
+		}
+	}
+	return nil
+}
+
+// nameMatches reports whether a pattern name addresses a name in the path,
+// either through one of its tag names or after normalizing both
+func nameMatches(pattern, name string, tagNames []string, normalize func(string) string) bool {
+	if slices.Contains(tagNames, pattern) {
+		return true
+	}
+	if normalize == nil {
+		return false
+	}
+	pattern = normalize(pattern)
+	if normalize(name) == pattern {
+		return true
+	}
+	for _, tagName := range tagNames {
+		if normalize(tagName) == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// isPathSensitive reports whether any configured path addresses path
+func (w *walker) isPathSensitive(path string) bool {
+	for _, pattern := range w.paths {
+		if pattern.matches(path, w.pathAliases, w.normalizeName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package yaredact
+
+import "reflect"
+
+// piiTag is the struct tag naming the personal data category of a field,
+// e.g. pii:"email". Fields with a non-empty pii tag are always sensitive.
+const piiTag = "pii"
+
+// WithPIIRedactors redacts fields tagged with a personal data category using
+// the redactor for that category instead of redactValue, e.g.
+//
+//	WithPIIRedactors(map[string]func(any) any{
+//		"email": MaskEmail(),
+//		"phone": MaskLastN(4),
+//	})
+//
+// masks `pii:"email"` and `pii:"phone"` fields differently. The redactor also
+// applies to sensitive values nested inside the field. Categories without a
+// redactor use redactValue.
+func WithPIIRedactors(redactors map[string]func(any) any) Option {
+	return func(c *config) {
+		c.piiRedactors = redactors
+	}
+}
+
+// fieldPIIRedactor returns the redactor for the pii category of a sensitive
+// field, or outer, the redactor in effect around the struct
+func (w *walker) fieldPIIRedactor(field reflect.StructField, sensitive bool, outer func(any) any) func(any) any {
+	if sensitive {
+		if redactor, ok := w.piiRedactors[field.Tag.Get(piiTag)]; ok {
+			return redactor
+		}
+	}
+	return outer
+}
@@ -0,0 +1,80 @@
+package yaredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPIITag(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "Password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Contact struct {
+		Name     string
+		Email    string   `pii:"email"`
+		Phone    string   `pii:"phone"`
+		Backups  []string `pii:"email"`
+		Address  string   `pii:"address"`
+		Password string
+		Nickname string `pii:""`
+	}
+
+	contact := Contact{
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Phone:    "+1 555 0100",
+		Backups:  []string{"a@example.org"},
+		Address:  "1 Main St",
+		Password: "hunter2",
+		Nickname: "al",
+	}
+
+	t.Run("TagMarksSensitive", func(t *testing.T) {
+		result := Redact(contact, isSensitive, redactValue)
+
+		if result.Email != "***REDACTED***" || result.Phone != "***REDACTED***" || result.Address != "***REDACTED***" {
+			t.Errorf("Expected pii fields to be redacted, got %+v", result)
+		}
+		if result.Name != "Alice" || result.Nickname != "al" {
+			t.Errorf("Expected fields without a pii category to be kept, got %+v", result)
+		}
+	})
+
+	t.Run("CategoryRedactors", func(t *testing.T) {
+		result := Redact(contact, isSensitive, redactValue, WithPIIRedactors(map[string]func(any) any{
+			"email": MaskEmail(),
+			"phone": MaskLastN(4),
+		}))
+
+		expected := Contact{
+			Name:     "Alice",
+			Email:    "***@example.com",
+			Phone:    "*******0100",
+			Backups:  []string{"a@example.org"},
+			Address:  "***REDACTED***",
+			Password: "***REDACTED***",
+			Nickname: "al",
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+		if contact.Email != "alice@example.com" {
+			t.Errorf("Original was modified")
+		}
+
+		result = Redact(contact, isSensitive, redactValue, WithRedactEntireSensitiveSubtree(), WithPIIRedactors(map[string]func(any) any{
+			"email": MaskEmail(),
+		}))
+		if len(result.Backups) != 1 || result.Backups[0] != "***@example.org" {
+			t.Errorf("Expected nested values of a pii field to use its redactor, got %v", result.Backups)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package yaredact
+
+import "reflect"
+
+// policyTag is the struct tag on a blank field that names the redaction
+// policy of the enclosing struct, e.g.
+//
+//	type Request struct {
+//		_ struct{} `redactpolicy:"strict"`
+//	}
+const policyTag = "redactpolicy"
+
+// Policy is a named pair of predicates used with WithPolicies. A nil
+// IsSensitive or RedactValue keeps the one in effect around the struct.
+type Policy struct {
+	IsSensitive func(string) bool
+	RedactValue func(any) any
+}
+
+// WithPolicies applies the named policy to every struct declaring it with a
+// `redactpolicy` tag on a blank field, and to everything below that struct
+// until a nested struct declares another policy. This lets different
+// subtrees of one value use different rules declaratively. Structs naming
+// an unknown policy keep the policy in effect around them.
+func WithPolicies(policies map[string]Policy) Option {
+	return func(c *config) {
+		c.policies = policies
+	}
+}
+
+// buildPolicies derives a config for each policy from c, with its own field
+// cache when c has one, since the policy changes how fields are matched
+func (c *config) buildPolicies() {
+	c.policyConfigs = nil
+	if len(c.policies) == 0 {
+		return
+	}
+	configs := make(map[string]*config, len(c.policies))
+	for name, policy := range c.policies {
+		pc := *c
+		if policy.IsSensitive != nil {
+			pc.rawIsSensitive = policy.IsSensitive
+		}
+		if policy.RedactValue != nil {
+			pc.redactValue = policy.RedactValue
+		}
+		if c.fields != nil {
+			pc.fields = newFieldCache()
+		}
+		pc.deriveIsSensitive()
+		configs[name] = &pc
+	}
+	for _, pc := range configs {
+		pc.policyConfigs = configs
+	}
+	c.policyConfigs = configs
+}
+
+// structPolicy returns the config of the policy declared by struct type t,
+// or nil if t declares none or an unknown one
+func (c *config) structPolicy(t reflect.Type) *config {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+		if name, ok := field.Tag.Lookup(policyTag); ok {
+			return c.policyConfigs[name]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package yaredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicies(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return name == "Password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Billing struct {
+		_      struct{} `redactpolicy:"pci"`
+		Holder string
+		Card   string
+		CVV    string
+	}
+
+	type Profile struct {
+		_        struct{} `redactpolicy:"strict"`
+		Name     string
+		Email    string
+		Password string
+	}
+
+	type Unknown struct {
+		_        struct{} `redactpolicy:"missing"`
+		Email    string
+		Password string
+	}
+
+	type Request struct {
+		ID       string
+		Password string
+		Profile  Profile
+		Billing  *Billing
+		Unknown  Unknown
+	}
+
+	policies := WithPolicies(map[string]Policy{
+		"strict": {
+			IsSensitive: func(name string) bool { return name != "Name" },
+			RedactValue: func(any) any { return "[strict]" },
+		},
+		"pci": {
+			IsSensitive: func(name string) bool { return name == "Card" || name == "CVV" },
+		},
+	})
+
+	req := Request{
+		ID:       "req-1",
+		Password: "hunter2",
+		Profile:  Profile{Name: "Alice", Email: "alice@example.com", Password: "p1"},
+		Billing:  &Billing{Holder: "Alice", Card: "4111111111111111", CVV: "123"},
+		Unknown:  Unknown{Email: "bob@example.com", Password: "p2"},
+	}
+
+	expected := Request{
+		ID:       "req-1",
+		Password: "***REDACTED***",
+		Profile:  Profile{Name: "Alice", Email: "[strict]", Password: "[strict]"},
+		Billing:  &Billing{Holder: "Alice", Card: "***REDACTED***", CVV: "***REDACTED***"},
+		Unknown:  Unknown{Email: "bob@example.com", Password: "***REDACTED***"},
+	}
+
+	t.Run("Redact", func(t *testing.T) {
+		result := Redact(req, isSensitive, redactValue, policies)
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+		if req.Profile.Email != "alice@example.com" || req.Billing.Card != "4111111111111111" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Redactor", func(t *testing.T) {
+		r := NewRedactor(isSensitive, redactValue, policies)
+
+		for i := 0; i < 2; i++ {
+			result := RedactWith(r, req)
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, result)
+			}
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		if _, err := RedactStrict(req, isSensitive, redactValue, policies); err != nil {
+			t.Errorf("Expected the policy marker field not to fail a strict redaction, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package yaredact
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// redactRawMessage redacts the JSON document held by a json.RawMessage at
+// path, like RedactJSON, and re-encodes it. Messages that aren't valid JSON
+// are copied verbatim.
+func (w *walker) redactRawMessage(v reflect.Value, path string) reflect.Value {
+	if v.IsNil() {
+		return v
+	}
+	raw := v.Bytes()
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil || decoded == nil {
+		return reflect.ValueOf(append(json.RawMessage(nil), raw...))
+	}
+	redacted := w.redactReflectValue(reflect.ValueOf(decoded), path)
+	encoded, err := json.Marshal(redacted.Interface())
+	if err != nil {
+		return reflect.ValueOf(append(json.RawMessage(nil), raw...))
+	}
+	return reflect.ValueOf(json.RawMessage(encoded))
+}
+
+// redactSensitiveRawMessage redacts a sensitive json.RawMessage at path. A
+// message holding a JSON string, number or bool is decoded first, so
+// redactValue sees the same value RedactJSON would pass it, and the result is
+// encoded back. Other messages, such as objects, are passed to redactValue as
+// is. A result that isn't a json.RawMessage, e.g. the string "***", is
+// encoded as JSON so the message stays valid.
+func (w *walker) redactSensitiveRawMessage(v reflect.Value, path string) (reflect.Value, bool) {
+	if scalar, ok := decodeRawScalar(v.Bytes()); ok {
+		redacted := w.redact(path, scalar)
+		if redacted == Drop {
+			return reflect.Value{}, true
+		}
+		encoded, err := json.Marshal(redacted)
+		if err != nil {
+			// Never fall back to the original scalar
+			return reflect.Zero(v.Type()), true
+		}
+		return reflect.ValueOf(json.RawMessage(encoded)), true
+	}
+
+	redacted := w.redact(path, v.Interface())
+	if result, ok := assignableResult(v.Type(), redacted); ok {
+		return result, true
+	}
+	encoded, err := json.Marshal(redacted)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(json.RawMessage(encoded)), true
+}
+
+// decodeRawScalar decodes raw if it holds a single JSON string, number or
+// bool. Numbers are decoded as json.Number, as in RedactJSON.
+func decodeRawScalar(raw []byte) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return nil, false
+	}
+	switch decoded.(type) {
+	case string, json.Number, bool:
+		return decoded, true
+	}
+	return nil, false
+}
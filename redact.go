@@ -1,139 +1,918 @@
 package yaredact
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"unsafe"
 )
 
 // Redact recursively processes data structures and redacts sensitive fields/keys
 // - For strings: returns as-is (doesn't redact standalone strings)
-// - For structs: redacts values of fields marked as sensitive (checking field names and json/xml/yaml/form/query/db/bson tags)
+// - For structs: redacts values of fields marked as sensitive (checking field names and json/xml/yaml/form/query/db/bson/protobuf tags)
 // - For maps: redacts values of keys marked as sensitive
 // - For slices/arrays: recursively processes each element
 // - For pointers: follows the pointer and processes the underlying value
-func Redact[T any](arg T, isSensitive func(string) bool, redactValue func(any) any) T {
+//
+// A sensitive struct, map, slice or array field is first handed to redactValue
+// whole. If the result is assignable to the field it is used as-is and the
+// nested fields are not inspected; otherwise the field is recursed into. Use
+// WithScalarsOnly to always recurse, WithSensitiveStructsWholesale to never
+// recurse, or WithRedactEntireSensitiveSubtree to redact every scalar inside.
+//
+// The original value is never modified: a pointer argument returns a new,
+// distinct pointer to a redacted copy unless WithPreservePointerIdentity is used.
+// Slices in the result have their own backing arrays with the same length and
+// capacity as the originals, so appending to or modifying them never affects
+// the input. Capacity beyond the length is zero-valued.
+//
+// Optional behaviour can be enabled by passing Option values, e.g. WithTypeHandlers.
+func Redact[T any](arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) T {
 	var zero T
 	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
 		return zero
 	}
 
+	w := newWalker(newConfig(isSensitive, redactValue, opts))
 	v := reflect.ValueOf(arg)
-	result := redactReflectValue(v, isSensitive, redactValue).Interface()
+	result := w.redactRoot(v).Interface()
 	return result.(T)
 }
 
+// RedactAny is like Redact but works on values already held as `any`,
+// returning the redacted value boxed in an `any`. A nil input returns nil.
+func RedactAny(arg any, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) any {
+	if arg == nil {
+		return nil
+	}
+
+	w := newWalker(newConfig(isSensitive, redactValue, opts))
+	return w.redactRoot(reflect.ValueOf(arg)).Interface()
+}
+
+// RedactIf redacts arg like Redact when enabled and returns arg itself,
+// without copying, when not, centralizing a development/production toggle:
+//
+//	yaredact.RedactIf(env == "production", req, isSensitive, redactValue)
+func RedactIf[T any](enabled bool, arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) T {
+	if !enabled {
+		return arg
+	}
+	return Redact(arg, isSensitive, redactValue, opts...)
+}
+
+// walker carries the state of a single traversal
+type walker struct {
+	*config
+	report  bool
+	records []RedactionRecord
+	depth   int
+	// inSensitiveSubtree is set below a sensitive field with WithRedactEntireSensitiveSubtree
+	inSensitiveSubtree bool
+	// strict makes unexported fields an error instead of zeroing them
+	strict bool
+	err    error
+	// nodes counts the values visited with WithMaxNodes, shared with children
+	nodes *atomic.Int64
+	// redactedNames is set with WithRedactOncePerName, shared with children
+	redactedNames *nameSet
+	// redactions counts redactValue calls with WithMaxRedactions, shared with children
+	redactions *atomic.Int64
+	// piiRedactor replaces redactValue within a field with a pii category
+	piiRedactor func(any) any
+	// inProgress maps the pointers, maps and slices being copied to their
+	// copies, so cyclic references are redacted once and point back into the copy
+	inProgress map[reference]reflect.Value
+	// walkFields holds the sensitive fields of the struct types seen so far
+	// when the config has no field cache
+	walkFields    []walkerFields
+	walkFieldsBuf [4]walkerFields
+	// pathAliases holds the tag names of the struct fields on the current
+	// path, so RedactPaths can address fields by e.g. their json names
+	pathAliases []pathAlias
+}
+
+// reference identifies a pointer, map or slice by address and type. Slices
+// also carry their length, as a slice and its sub-slices share an address.
+type reference struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+// referenceOf returns the reference of the pointer, map or slice v
+func referenceOf(v reflect.Value) reference {
+	ref := reference{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		ref.len = v.Len()
+	}
+	return ref
+}
+
+// references is the set of pointers, maps and slices on the current path of
+// a read-only traversal, used to stop at cyclic references
+type references map[reference]bool
+
+// enter adds the pointer, map or slice v to the set, reporting false if it
+// is already on the path
+func (r references) enter(v reflect.Value) bool {
+	ref := referenceOf(v)
+	if r[ref] {
+		return false
+	}
+	r[ref] = true
+	return true
+}
+
+// leave removes the pointer, map or slice v from the set
+func (r references) leave(v reflect.Value) {
+	delete(r, referenceOf(v))
+}
+
+// enter registers the copy of the pointer, map or slice v while its contents
+// are redacted, returning the copy if v is already being copied further up
+func (w *walker) enter(v, copied reflect.Value) (reflect.Value, bool) {
+	ref := referenceOf(v)
+	if existing, ok := w.inProgress[ref]; ok {
+		return existing, true
+	}
+	if w.inProgress == nil {
+		w.inProgress = map[reference]reflect.Value{}
+	}
+	w.inProgress[ref] = copied
+	return copied, false
+}
+
+// leave unregisters the pointer, map or slice v once its contents are redacted
+func (w *walker) leave(v reflect.Value) {
+	delete(w.inProgress, referenceOf(v))
+}
+
+func newWalker(c *config) *walker {
+	w := &walker{config: c}
+	if c.maxNodes > 0 {
+		w.nodes = new(atomic.Int64)
+	}
+	if c.maxRedactions > 0 {
+		w.redactions = new(atomic.Int64)
+	}
+	if c.redactOncePerName {
+		w.redactedNames = &nameSet{names: map[string]bool{}}
+	}
+	return w
+}
+
+// redactRoot redacts the value passed to an entry point
+func (w *walker) redactRoot(v reflect.Value) reflect.Value {
+	if w.preservePointerIdentity && v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().CanSet() {
+		// Write the redacted value back through the caller's pointer
+		redacted := w.redactReflectValue(v.Elem(), "")
+		writeBack(v.Elem(), redacted, map[reference]bool{})
+		return v
+	}
+	return w.redactReflectValue(v, "")
+}
+
+// writeBack copies the parts of the redacted copy src that differ from dst
+// into dst in place, following pointers instead of replacing them. Unexported
+// fields, which the copy leaves zero-valued, are never written, so the
+// caller's private state and mutexes stay untouched.
+func writeBack(dst, src reflect.Value, visited map[reference]bool) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Type().Field(i).IsExported() {
+				writeBack(dst.Field(i), src.Field(i), visited)
+			}
+		}
+		return
+
+	case reflect.Ptr:
+		if !dst.IsNil() && !src.IsNil() {
+			ref := referenceOf(dst)
+			if !visited[ref] {
+				visited[ref] = true
+				writeBack(dst.Elem(), src.Elem(), visited)
+			}
+			return
+		}
+
+	case reflect.Interface:
+		// Values boxed in interfaces can only be updated through a non-nil
+		// pointer, anything else replaces the interface value itself
+		if !dst.IsNil() && !src.IsNil() {
+			d, s := dst.Elem(), src.Elem()
+			if d.Kind() == reflect.Ptr && d.Type() == s.Type() && !d.IsNil() && !s.IsNil() && d.Elem().CanSet() {
+				writeBack(d, s, visited)
+				return
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		sameLen := dst.Kind() == reflect.Array || (!dst.IsNil() && !src.IsNil() && dst.Len() == src.Len())
+		if sameLen && !isBasicKind(dst.Type().Elem().Kind()) {
+			if dst.Kind() == reflect.Slice && dst.Len() > 0 {
+				ref := referenceOf(dst)
+				if visited[ref] {
+					return
+				}
+				visited[ref] = true
+			}
+			for i := 0; i < dst.Len(); i++ {
+				writeBack(dst.Index(i), src.Index(i), visited)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(dst.Interface(), src.Interface()) {
+		dst.Set(src)
+	}
+}
+
+// record notes that the value at path was redacted, when a report or
+// metrics were requested
+func (w *walker) record(path, name string, kind reflect.Kind) {
+	if w.metrics != nil {
+		w.metrics.IncRedacted(kind)
+	}
+	if w.report {
+		w.records = append(w.records, RedactionRecord{Path: path, FieldName: name, Kind: kind})
+	}
+}
+
+// joinPath appends a field name or map key to a dotted path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// indexPath appends a slice or array index to a path
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// indexPath appends a slice or array index to a path, or "[]" with
+// WithCollapseIndices
+func (c *config) indexPath(path string, i int) string {
+	if c.collapseIndices {
+		return path + "[]"
+	}
+	return indexPath(path, i)
+}
+
 // isFieldSensitive checks if a struct field should be considered sensitive
 // by examining both the field name and its struct tags (json, xml, yaml, etc.)
-func isFieldSensitive(field reflect.StructField, isSensitive func(string) bool) bool {
+// according to the configured TagMatch mode
+func (c *config) isFieldSensitive(field reflect.StructField) bool {
+	// JSON schema markers for secrets, e.g. jsonschema:"format=password"
+	if hasSchemaSecretMarker(field.Tag.Get("jsonschema")) {
+		return true
+	}
+
+	// Personal data categories, e.g. pii:"email"
+	if field.Tag.Get(piiTag) != "" {
+		return true
+	}
+
 	// Check the field name itself
-	if isSensitive(field.Name) {
+	if c.tagMatch != AllTags && c.isSensitive(field.Name) {
 		return true
 	}
 
 	// Check common struct tags
-	tagNames := []string{"json", "xml", "yaml", "form", "query", "db", "bson"}
-	for _, tagName := range tagNames {
+	tagCount := 0
+	for _, tagName := range c.tagNames {
 		if tagValue := field.Tag.Get(tagName); tagValue != "" {
-			// Extract the actual name from the tag (before any comma-separated options)
-			// e.g., "password,omitempty" -> "password"
-			tagFieldName := strings.Split(tagValue, ",")[0]
+			tagFieldNames := tagNamesOf(tagValue)
 
 			// Skip if it's a dash (which means ignore this field in marshaling)
-			if tagFieldName == "-" {
+			if tagFieldNames[0] == "-" {
 				continue
 			}
 
-			// Check if this tag name indicates sensitivity
-			if isSensitive(tagFieldName) {
-				return true
+			switch c.tagMatch {
+			case AllTags:
+				// Every tag must indicate sensitivity
+				if !c.anySensitive(tagFieldNames) {
+					return false
+				}
+				tagCount++
+			case FullTagValue:
+				// Check the raw tag value, including options
+				if c.isSensitive(tagValue) {
+					return true
+				}
+			case TagOptions:
+				// Check the tag names and every option
+				if c.anySensitive(tagFieldNames) || c.anySensitive(strings.Split(tagValue, ",")[1:]) {
+					return true
+				}
+			default:
+				// Check if this tag name indicates sensitivity
+				if c.anySensitive(tagFieldNames) {
+					return true
+				}
 			}
 		}
 	}
 
+	if c.tagMatch == AllTags {
+		// Without any tags, fall back to the field name
+		if tagCount == 0 {
+			return c.isSensitive(field.Name)
+		}
+		return true
+	}
+
+	return false
+}
+
+// tagNamesOf extracts the names carried by a tag value: the leading token,
+// e.g. "password,omitempty" -> "password", followed by any name= options of
+// protobuf-style tags, e.g. "bytes,3,opt,name=token" -> "bytes", "token"
+func tagNamesOf(tagValue string) []string {
+	parts := strings.Split(tagValue, ",")
+	names := parts[:1]
+	for _, part := range parts[1:] {
+		if name, ok := strings.CutPrefix(part, "name="); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// hasSchemaSecretMarker reports whether a jsonschema tag value marks the
+// field as a secret with format=password or writeOnly=true, the OpenAPI
+// conventions for passwords and other values that are never read back
+func hasSchemaSecretMarker(tagValue string) bool {
+	for tagValue != "" {
+		var option string
+		option, tagValue, _ = strings.Cut(tagValue, ",")
+		switch strings.TrimSpace(option) {
+		case "format=password", "writeOnly=true":
+			return true
+		}
+	}
+	return false
+}
+
+// anySensitive reports whether isSensitive matches any of names
+func (c *config) anySensitive(names []string) bool {
+	for _, name := range names {
+		if c.isSensitive(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactPointerChain follows a chain of pointers (e.g. **string) down to the
+// first non-pointer value, passes it to redactValue and rebuilds the chain
+// around the result. It reports false if any pointer in the chain is nil or
+// the redacted value isn't assignable to the original type.
+func (w *walker) redactPointerChain(ptr reflect.Value, path, name string) (reflect.Value, bool) {
+	elem := ptr.Elem()
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return reflect.Value{}, false
+		}
+		redacted, ok := w.redactPointerChain(elem, path, name)
+		if !ok || !redacted.IsValid() {
+			// Mismatch or dropped value, propagate up the chain
+			return redacted, ok
+		}
+		result := reflect.New(elem.Type())
+		result.Elem().Set(redacted)
+		return result, true
+	}
+
+	if !elem.CanInterface() {
+		return reflect.Value{}, false
+	}
+	redacted, ok := w.redactSensitive(elem, path)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	w.record(path, name, elem.Kind())
+	if !redacted.IsValid() {
+		// Dropped, the whole chain is left nil
+		return redacted, true
+	}
+
+	// Create a new pointer to the redacted value
+	result := reflect.New(elem.Type())
+	result.Elem().Set(redacted)
+	return result, true
+}
+
+// redactSensitive passes the sensitive value v at path to redactValue and
+// returns the result as a value of v's type, reporting false if it isn't
+// assignable. An invalid value with true means redactValue returned Drop.
+func (w *walker) redactSensitive(v reflect.Value, path string) (reflect.Value, bool) {
+	if w.scalarsOnly && !isScalar(v) {
+		// Containers are recursed instead of being handed to redactValue
+		return reflect.Value{}, false
+	}
+	if isTextType(v.Type()) && !isBigType(v.Type()) {
+		return w.redactText(v, path), true
+	}
+	if v.Type() == rawMessageType {
+		return w.redactSensitiveRawMessage(v, path)
+	}
+	if isNullType(v.Type()) {
+		return w.redactNull(v, path)
+	}
+	return assignableResult(v.Type(), w.redact(path, v.Interface()))
+}
+
+// redact passes a sensitive value at path to redactValue, or the fallback
+// once WithMaxRedactions is exceeded, followed by the post-processing hook if
+// one is configured
+func (w *walker) redact(path string, x any) any {
+	redactValue := w.redactValue
+	if w.piiRedactor != nil {
+		redactValue = w.piiRedactor
+	}
+	if w.redactions != nil && w.redactions.Add(1) > int64(w.maxRedactions) {
+		redactValue = w.fallbackRedactValue
+	}
+	redacted := redactValue(x)
+	if s, ok := redacted.(string); ok && len(w.scrubPatterns) > 0 {
+		redacted = w.scrub(s)
+	}
+	if w.postProcess != nil && redacted != Drop {
+		redacted = w.postProcess(path, redacted)
+	}
+	return redacted
+}
+
+// scrub replaces every match of the WithScrubSubstrings patterns in s
+func (c *config) scrub(s string) string {
+	for _, pattern := range c.scrubPatterns {
+		s = pattern.ReplaceAllString(s, c.scrubReplacement)
+	}
+	return s
+}
+
+// isScalar reports whether v, or the value boxed inside it, is a string,
+// number, bool, []byte, text-encodable, sql.Null* or opaque Stringer value
+func isScalar(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if isBasicKind(v.Kind()) {
+		return true
+	}
+	if v.Kind() == reflect.Slice {
+		return v.Type().Elem().Kind() == reflect.Uint8
+	}
+	return isTextType(v.Type()) || isNullType(v.Type()) || isStringerLeaf(v.Type())
+}
+
+// assignableResult converts the value returned by a callback into a value of
+// type t, reporting false if it isn't assignable to t. Basic values of the
+// same kind are converted, e.g. int64 to time.Duration. Nil becomes the zero
+// value of nilable types, and Drop becomes an invalid value with true.
+func assignableResult(t reflect.Type, x any) (reflect.Value, bool) {
+	if x == Drop {
+		return reflect.Value{}, true
+	}
+	if x == nil && canBeNil(t.Kind()) {
+		return reflect.Zero(t), true
+	}
+	redactedReflect := reflect.ValueOf(x)
+	if !redactedReflect.IsValid() {
+		return reflect.Value{}, false
+	}
+	if !redactedReflect.Type().AssignableTo(t) {
+		// A plain int64 for a time.Duration, string for a named string, ...
+		if redactedReflect.Kind() != t.Kind() || !isBasicKind(t.Kind()) {
+			return reflect.Value{}, false
+		}
+		redactedReflect = redactedReflect.Convert(t)
+	}
+	result := reflect.New(t).Elem()
+	result.Set(redactedReflect)
+	return result, true
+}
+
+// isBasicKind reports whether k is a bool, number or string kind, whose
+// values convert between named types of the same kind
+func isBasicKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// zeroIfDropped turns a dropped result into the zero value of type t, for
+// places like slice elements that can't be removed
+func zeroIfDropped(result reflect.Value, t reflect.Type) reflect.Value {
+	if !result.IsValid() {
+		return reflect.Zero(t)
+	}
+	return result
+}
+
+// omittedPlaceholder replaces strings beyond the WithMaxNodes budget
+const omittedPlaceholder = "<omitted>"
+
+// omitted returns the placeholder for v once the node budget is spent:
+// "<omitted>" for strings, otherwise the zero value
+func omitted(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.String {
+		return reflect.ValueOf(omittedPlaceholder).Convert(v.Type())
+	}
+	return reflect.Zero(v.Type())
+}
+
+// canBeNil reports whether values of kind k can be nil
+func canBeNil(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
+
+// isMapEntrySensitive checks if a map entry should be redacted, using the
+// key and value predicate when configured and the key name otherwise
+func (w *walker) isMapEntrySensitive(key, value reflect.Value, keyStr string) bool {
+	if w.isSensitiveMapEntry != nil {
+		return key.CanInterface() && value.CanInterface() &&
+			w.isSensitiveMapEntry(key.Interface(), value.Interface())
+	}
+	return keyStr != "" && w.isSensitive(keyStr)
+}
+
+// mapKeyString converts a map key to the name checked with isSensitive.
+// Keys that aren't strings use their String method when they have one and
+// their fmt.Sprint formatting otherwise.
+func mapKeyString(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	if !key.CanInterface() {
+		return ""
+	}
+	if stringer, ok := key.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprint(key.Interface())
+}
+
+// isIgnored reports whether v, or the value boxed inside it, has a type
+// excluded with WithIgnoreTypes or implementing NoRedact
+func (c *config) isIgnored(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return c.ignoreTypes[v.Type()] || isNoRedact(v.Type())
+}
+
+// isElementList reports whether v, or the value boxed inside it, is a slice
+// or array of values other than bytes
+func isElementList(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// redactSensitiveElements passes each element of the sensitive slice or
+// array v at path to redactValue, recursing into elements whose redacted
+// value isn't assignable. Dropped elements are left zero-valued.
+func (w *walker) redactSensitiveElements(v reflect.Value, path, name string) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		return w.redactSensitiveElements(v.Elem(), path, name)
+	}
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return v
+	}
+
+	var result reflect.Value
+	if v.Kind() == reflect.Slice {
+		result = reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+	} else {
+		result = reflect.New(v.Type()).Elem()
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		elemPath := w.indexPath(path, i)
+		if redacted, ok := w.redactSensitive(elem, elemPath); ok {
+			result.Index(i).Set(zeroIfDropped(redacted, elem.Type()))
+			w.record(elemPath, name, elem.Kind())
+		} else {
+			w.redactInto(result.Index(i), elem, elemPath)
+		}
+	}
+	return result
+}
+
+// indirectKind returns the kind of the value v points to or boxes, following
+// any chain of non-nil pointers and interfaces
+func indirectKind(v reflect.Value) reflect.Kind {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.Kind()
+}
+
+// redactSubtree redacts v with every scalar inside it treated as sensitive
+func (w *walker) redactSubtree(v reflect.Value, path string) reflect.Value {
+	outer := w.inSensitiveSubtree
+	w.inSensitiveSubtree = true
+	defer func() { w.inSensitiveSubtree = outer }()
+	return w.redactReflectValue(v, path)
+}
+
+// isContainerKind reports whether values of kind k hold other values
+func isContainerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
 	return false
 }
 
-func redactReflectValue(v reflect.Value, isSensitive func(string) bool, redactValue func(any) any) reflect.Value {
+// exposeField returns a readable and settable view of a field of an
+// addressable struct, bypassing the restriction on unexported fields
+func exposeField(field reflect.Value) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// isZeroValue reports whether v, or the value boxed inside it, is a zero value
+func isZeroValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.IsZero()
+}
+
+// redactLeaf handles the values that redactReflectValue redacts or copies
+// whole, before any traversal: values beyond the node budget, ignored types,
+// path, type handler and sensitive type matches, scalars in a sensitive
+// subtree and big numbers. It also returns the traits of v's type.
+func (w *walker) redactLeaf(v reflect.Value, path string) (result reflect.Value, traits typeTraits, done bool) {
+	// Values beyond the node budget are omitted. Pointers and interfaces
+	// only box the value they hold and aren't counted.
+	if w.nodes != nil && v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface && w.nodes.Add(1) > int64(w.maxNodes) {
+		return omitted(v), traits, true
+	}
+
+	// Ignored and NoRedact types are shared as-is, never walked or copied
+	traits = traitsOf(v.Type())
+	if w.ignoreTypes[v.Type()] || traits&traitNoRedact != 0 {
+		return v, traits, true
+	}
+
+	// Values addressed by RedactPaths; pointers and interfaces are unwrapped first
+	if len(w.paths) > 0 && v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface &&
+		v.CanInterface() && w.isPathSensitive(path) {
+		if result, ok := w.redactSensitive(v, path); ok {
+			w.record(path, "", v.Kind())
+			return zeroIfDropped(result, v.Type()), traits, true
+		}
+	}
+
+	// Registered type handlers take precedence over the generic traversal
+	if handler, ok := w.typeHandlers[v.Type()]; ok && v.CanInterface() {
+		if result, ok := assignableResult(v.Type(), handler(v.Interface())); ok {
+			w.record(path, "", v.Kind())
+			return zeroIfDropped(result, v.Type()), traits, true
+		}
+	}
+
+	// Values of sensitive types are redacted regardless of field or key names
+	if w.isSensitiveType != nil && v.CanInterface() && w.isSensitiveType(v.Type()) &&
+		(!w.scalarsOnly || isScalar(v)) {
+		if result, ok := assignableResult(v.Type(), w.redact(path, v.Interface())); ok {
+			w.record(path, "", v.Kind())
+			return zeroIfDropped(result, v.Type()), traits, true
+		}
+	}
+
+	// Scalars under a sensitive field or key are redacted regardless of their names
+	if w.inSensitiveSubtree && v.Kind() != reflect.Interface && v.CanInterface() && isScalar(v) {
+		if result, ok := w.redactSensitive(v, path); ok {
+			w.record(path, "", v.Kind())
+			return zeroIfDropped(result, v.Type()), traits, true
+		}
+	}
+
+	// Arbitrary-precision numbers are atomic, copy them deeply
+	if isBigType(v.Type()) {
+		return copyBig(v), traits, true
+	}
+
+	return reflect.Value{}, traits, false
+}
+
+// redactInto redacts v at path into the settable dst. A sync.Map is filled
+// in place, since it must not be copied once used.
+func (w *walker) redactInto(dst, v reflect.Value, path string) {
+	if v.IsValid() && v.Type() == syncMapType && dst.CanAddr() {
+		if result, _, done := w.redactLeaf(v, path); done {
+			dst.Set(result)
+			return
+		}
+		w.redactSyncMap(v, path, dst)
+		return
+	}
+	if redacted := w.redactReflectValue(v, path); redacted.IsValid() {
+		dst.Set(redacted)
+	}
+}
+
+func (w *walker) redactReflectValue(v reflect.Value, path string) reflect.Value {
 	if !v.IsValid() {
 		return v
 	}
+	result, traits, done := w.redactLeaf(v, path)
+	if done {
+		return result
+	}
+
+	// sync.Map internals are unexported, its entries are redacted via Range
+	if v.Type() == syncMapType {
+		result := reflect.New(syncMapType).Elem()
+		w.redactSyncMap(v, path, result)
+		return result
+	}
+
+	// Embedded JSON documents are redacted like RedactJSON
+	if v.Type() == rawMessageType {
+		return w.redactRawMessage(v, path)
+	}
+
+	// Text-encodable values (time.Time, net.IP, ...) are atomic, copy them verbatim
+	if traits&traitText != 0 {
+		if v.Kind() == reflect.Slice && !v.IsNil() {
+			// Don't share the backing array of slice types like net.IP
+			result := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+			reflect.Copy(result, v)
+			return result
+		}
+		return v
+	}
+
+	// Opaque Stringer types (IDs, enums, ...) are atomic, copy them verbatim
+	if traits&traitStringerLeaf != 0 {
+		return v
+	}
+
+	// Values nested deeper than the maximum depth are not copied
+	if isContainerKind(v.Kind()) {
+		w.depth++
+		defer func() { w.depth-- }()
+		if w.maxDepth > 0 && w.depth > w.maxDepth {
+			return reflect.Zero(v.Type())
+		}
+	}
 
 	switch v.Kind() {
 	case reflect.Ptr:
 		if v.IsNil() {
 			return v
 		}
-		// Create a new pointer to the redacted value
+		// Create a new pointer to the redacted value. The pointer keeps the
+		// original element type, e.g. *any rather than the redacted value's
+		// dynamic type.
 		elem := v.Elem()
-		redacted := redactReflectValue(elem, isSensitive, redactValue)
-		ptr := reflect.New(redacted.Type())
-		ptr.Elem().Set(redacted)
+		ptr, cyclic := w.enter(v, reflect.New(elem.Type()))
+		if cyclic {
+			return ptr
+		}
+		w.redactInto(ptr.Elem(), elem, path)
+		w.leave(v)
 		return ptr
 
 	case reflect.Interface:
 		if v.IsNil() {
 			return v
 		}
+		// Typed nils, e.g. an error holding a nil *MyError, are copied as-is
+		if elem := v.Elem(); canBeNil(elem.Kind()) && elem.IsNil() {
+			return v
+		}
+		// Errors are opaque values, don't walk their internals
+		if v.CanInterface() {
+			if err, ok := v.Interface().(error); ok {
+				return w.redactError(v, err, path)
+			}
+		}
 		// Redact the underlying value and wrap it back in an interface
 		elem := v.Elem()
-		redacted := redactReflectValue(elem, isSensitive, redactValue)
+		redacted := w.redactReflectValue(elem, path)
 		return redacted
 
 	case reflect.Struct:
+		// Unexported fields can only be read through an addressable value
+		if w.includeUnexported && !v.CanAddr() {
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v = addressable
+		}
+
+		// A struct declaring a policy switches to it for its whole subtree
+		if w.policyConfigs != nil {
+			if policy := w.structPolicy(v.Type()); policy != nil {
+				outer := w.config
+				w.config = policy
+				defer func() { w.config = outer }()
+			}
+		}
+
 		// Create a new struct with redacted fields
 		result := reflect.New(v.Type()).Elem()
+		sensitiveFields := w.sensitiveFields(v.Type())
+		valueIndex := w.keyValueIndex(v)
+		outerRedactor := w.piiRedactor
+		if w.piiRedactors != nil {
+			defer func() { w.piiRedactor = outerRedactor }()
+		}
+		aliasDepth := len(w.pathAliases)
 		for i := 0; i < v.NumField(); i++ {
 			field := v.Field(i)
+			dst := result.Field(i)
 			fieldType := v.Type().Field(i)
+			fieldPath := joinPath(path, fieldType.Name)
+			if len(w.paths) > 0 {
+				w.pathAliases = append(w.pathAliases[:aliasDepth], pathAlias{end: len(fieldPath), names: w.fieldTagNames(fieldType)})
+			}
 
 			// Check if we can set this field (must be exported)
-			if result.Field(i).CanSet() {
-				// Check if field is sensitive by name or by struct tags
-				fieldIsSensitive := isFieldSensitive(fieldType, isSensitive)
-
-				if fieldIsSensitive && field.CanInterface() {
-					// Field is sensitive - apply redaction callback
-					// Special handling for pointer types: dereference, redact, then re-wrap
-					if field.Kind() == reflect.Ptr && !field.IsNil() {
-						elem := field.Elem()
-						if elem.CanInterface() {
-							originalValue := elem.Interface()
-							redactedValue := redactValue(originalValue)
-							redactedReflect := reflect.ValueOf(redactedValue)
-
-							// Create a new pointer to the redacted value
-							if redactedReflect.Type().AssignableTo(elem.Type()) {
-								ptr := reflect.New(redactedReflect.Type())
-								ptr.Elem().Set(redactedReflect)
-								result.Field(i).Set(ptr)
-							} else {
-								// Type mismatch - recursively process instead
-								redacted := redactReflectValue(field, isSensitive, redactValue)
-								result.Field(i).Set(redacted)
-							}
+			if !dst.CanSet() {
+				if !w.includeUnexported {
+					// Unexported fields are left zero-valued, or fail a strict redaction.
+					// Blank fields such as a redactpolicy marker hold no data.
+					if w.strict && w.err == nil && fieldType.Name != "_" {
+						w.err = &UnexportedFieldError{Path: fieldPath, Type: v.Type()}
+					}
+					continue
+				}
+				field = exposeField(field)
+				dst = exposeField(dst)
+			}
+
+			// Check if field is sensitive by name or by struct tags, or is the
+			// value of a key/value pair with a sensitive key
+			fieldIsSensitive := (sensitiveFields[i] || i == valueIndex) && !w.isIgnored(field) && w.firstRedaction(fieldType.Name)
+			if w.piiRedactors != nil {
+				w.piiRedactor = w.fieldPIIRedactor(fieldType, fieldIsSensitive, outerRedactor)
+			}
+
+			if fieldIsSensitive && w.skipZeroValues && field.IsZero() {
+				// Zero-valued sensitive field - leave it as the zero value
+				continue
+			}
+
+			if fieldIsSensitive && w.redactEntireSubtree && isContainerKind(indirectKind(field)) {
+				// Every scalar under the sensitive field is redacted
+				dst.Set(w.redactSubtree(field, fieldPath))
+			} else if fieldIsSensitive && field.CanInterface() {
+				// Field is sensitive - apply redaction callback
+				// Special handling for pointer types: dereference, redact, then re-wrap
+				// Pointers to big numbers are handed to redactValue whole
+				if field.Kind() == reflect.Ptr && !field.IsNil() && !isBigType(field.Type().Elem()) {
+					if redacted, ok := w.redactPointerChain(field, fieldPath, fieldType.Name); ok {
+						if redacted.IsValid() {
+							dst.Set(redacted)
 						}
 					} else {
-						// Non-pointer sensitive field
-						originalValue := field.Interface()
-						redactedValue := redactValue(originalValue)
-
-						// Set the redacted value back
-						redactedReflect := reflect.ValueOf(redactedValue)
-						if redactedReflect.Type().AssignableTo(field.Type()) {
-							result.Field(i).Set(redactedReflect)
-						} else {
-							// Type mismatch - recursively process instead
-							redacted := redactReflectValue(field, isSensitive, redactValue)
-							result.Field(i).Set(redacted)
-						}
+						// Nil inner pointer or type mismatch - recursively process instead
+						w.redactInto(dst, field, fieldPath)
 					}
 				} else {
-					// For non-sensitive fields, recursively process
-					redacted := redactReflectValue(field, isSensitive, redactValue)
-					result.Field(i).Set(redacted)
+					// Non-pointer sensitive field, set the redacted value back
+					if redacted, ok := w.redactSensitive(field, fieldPath); ok {
+						// Dropped fields can't be removed from a struct, leave them zero-valued
+						if redacted.IsValid() {
+							dst.Set(redacted)
+						}
+						w.record(fieldPath, fieldType.Name, field.Kind())
+					} else if w.redactWholesale && isContainerKind(field.Kind()) {
+						// Sensitive containers are never walked - leave the zero value
+						continue
+					} else {
+						// Type mismatch - recursively process instead
+						w.redactInto(dst, field, fieldPath)
+					}
 				}
+			} else {
+				// For non-sensitive fields, recursively process
+				w.redactInto(dst, field, fieldPath)
 			}
 		}
+		w.pathAliases = w.pathAliases[:aliasDepth]
 		return result
 
 	case reflect.Map:
@@ -141,29 +920,60 @@ func redactReflectValue(v reflect.Value, isSensitive func(string) bool, redactVa
 			return v
 		}
 		// Create a new map with redacted values for sensitive keys
-		result := reflect.MakeMap(v.Type())
+		result, cyclic := w.enter(v, reflect.MakeMap(v.Type()))
+		if cyclic {
+			return result
+		}
+		defer w.leave(v)
 		for _, key := range v.MapKeys() {
 			value := v.MapIndex(key)
 
 			// Check if the key is sensitive (convert key to string if possible)
-			keyStr := ""
-			if key.Kind() == reflect.String {
-				keyStr = key.String()
-			} else {
-				// Try to convert key to string using fmt.Sprint equivalent
-				if key.CanInterface() {
-					keyStr = reflect.ValueOf(key.Interface()).String()
-				}
+			keyStr := mapKeyString(key)
+			entryIsSensitive := w.isMapEntrySensitive(key, value, keyStr) && !w.isIgnored(value) && w.firstRedaction(keyStr)
+
+			// Hide the sensitive key name itself
+			if entryIsSensitive && w.redactKeyNames && key.Kind() == reflect.String {
+				maskedKey := reflect.New(key.Type()).Elem()
+				maskedKey.SetString(redactedKeyName)
+				key = maskedKey
 			}
 
-			if keyStr != "" && isSensitive(keyStr) && value.CanInterface() {
+			if entryIsSensitive && w.skipZeroValues && isZeroValue(value) {
+				// Zero-valued sensitive entry - keep the zero value as-is
+				result.SetMapIndex(key, value)
+			} else if entryIsSensitive && w.redactEntireSubtree && isContainerKind(indirectKind(value)) {
+				// Every scalar under the sensitive key is redacted
+				result.SetMapIndex(key, w.redactSubtree(value, joinPath(path, keyStr)))
+			} else if entryIsSensitive && value.CanInterface() && isElementList(value) {
+				// Sensitive lists, e.g. form values, have each element redacted
+				result.SetMapIndex(key, w.redactSensitiveElements(value, joinPath(path, keyStr), keyStr))
+			} else if entryIsSensitive && value.Kind() == reflect.Ptr && !value.IsNil() && !isBigType(value.Type().Elem()) {
+				// Sensitive pointers are redacted through the pointer, like struct fields
+				if redacted, ok := w.redactPointerChain(value, joinPath(path, keyStr), keyStr); ok {
+					if redacted.IsValid() {
+						result.SetMapIndex(key, redacted)
+					}
+				} else {
+					// Nil inner pointer or type mismatch - recursively process instead
+					result.SetMapIndex(key, w.redactReflectValue(value, joinPath(path, keyStr)))
+				}
+			} else if entryIsSensitive && value.CanInterface() {
 				// Redact the value for sensitive keys
-				originalValue := value.Interface()
-				redactedValue := redactValue(originalValue)
-				result.SetMapIndex(key, reflect.ValueOf(redactedValue))
+				if redacted, ok := w.redactSensitive(value, joinPath(path, keyStr)); ok {
+					// Dropped entries are omitted from the map
+					if redacted.IsValid() {
+						result.SetMapIndex(key, redacted)
+					}
+					w.record(joinPath(path, keyStr), keyStr, value.Kind())
+				} else {
+					// Type mismatch - recursively process instead
+					redacted := w.redactReflectValue(value, joinPath(path, keyStr))
+					result.SetMapIndex(key, redacted)
+				}
 			} else {
 				// For non-sensitive keys, recursively process the value
-				redacted := redactReflectValue(value, isSensitive, redactValue)
+				redacted := w.redactReflectValue(value, joinPath(path, keyStr))
 				result.SetMapIndex(key, redacted)
 			}
 		}
@@ -175,24 +985,52 @@ func redactReflectValue(v reflect.Value, isSensitive func(string) bool, redactVa
 		}
 		// Create a new slice with redacted elements
 		result := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
-		for i := 0; i < v.Len(); i++ {
-			elem := v.Index(i)
-			redacted := redactReflectValue(elem, isSensitive, redactValue)
-			result.Index(i).Set(redacted)
+		if w.copiesElements(v.Type().Elem()) {
+			reflect.Copy(result, v)
+			return result
 		}
+		if v.Len() == 0 {
+			return result
+		}
+		// A slice holding itself, e.g. through an []any, points back into the copy
+		result, cyclic := w.enter(v, result)
+		if cyclic {
+			return result
+		}
+		w.redactElements(v, result, path)
+		w.leave(v)
 		return result
 
 	case reflect.Array:
 		// Create a new array with redacted elements
 		result := reflect.New(v.Type()).Elem()
-		for i := 0; i < v.Len(); i++ {
-			elem := v.Index(i)
-			redacted := redactReflectValue(elem, isSensitive, redactValue)
-			result.Index(i).Set(redacted)
+		if w.copiesElements(v.Type().Elem()) {
+			result.Set(v)
+			return result
 		}
+		w.redactElements(v, result, path)
 		return result
 
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// Channels and funcs have no copyable contents, share them as-is
+		return v
+
 	case reflect.String:
+		// Strings that look sensitive are redacted with RedactByValue
+		if w.isSensitiveValue != nil && v.CanInterface() && w.isSensitiveValue(v.String()) {
+			if result, ok := w.redactSensitive(v, path); ok {
+				w.record(path, "", v.Kind())
+				return zeroIfDropped(result, v.Type())
+			}
+		}
+		// Embedded secrets are scrubbed from every string
+		if len(w.scrubPatterns) > 0 && v.CanInterface() {
+			if scrubbed := w.scrub(v.String()); scrubbed != v.String() {
+				result := reflect.New(v.Type()).Elem()
+				result.SetString(scrubbed)
+				return result
+			}
+		}
 		// Standalone strings are not redacted, return as-is
 		return v
 
@@ -1,6 +1,9 @@
 package yaredact
 
 import (
+	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -339,6 +342,42 @@ func TestRedact(t *testing.T) {
 		}
 	})
 
+	t.Run("Protobuf Name Tag", func(t *testing.T) {
+		type Credentials struct {
+			Value    string `protobuf:"bytes,3,opt,name=token,proto3"`
+			Username string `protobuf:"bytes,1,opt,name=username,proto3"`
+		}
+
+		creds := Credentials{Value: "abc123", Username: "alice"}
+
+		result := Redact(creds, isSensitive, redactValue)
+
+		if result.Value != "***REDACTED***" {
+			t.Errorf("Expected Value to be redacted (name=token), got %s", result.Value)
+		}
+		if result.Username != "alice" {
+			t.Errorf("Expected Username to be 'alice', got %s", result.Username)
+		}
+	})
+
+	t.Run("JSON Schema Markers", func(t *testing.T) {
+		type SignupRequest struct {
+			Login      string `json:"login" jsonschema:"title=Login,minLength=3"`
+			Passphrase string `json:"passphrase" jsonschema:"title=Passphrase,format=password"`
+			Recovery   string `json:"recovery" jsonschema:"writeOnly=true"`
+			Email      string `json:"email" jsonschema:"format=email"`
+		}
+
+		request := SignupRequest{Login: "alice", Passphrase: "p", Recovery: "r", Email: "a@b.c"}
+
+		result := Redact(request, isSensitive, redactValue)
+
+		expected := SignupRequest{Login: "alice", Passphrase: "***REDACTED***", Recovery: "***REDACTED***", Email: "a@b.c"}
+		if result != expected {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	})
+
 	t.Run("Nil Values", func(t *testing.T) {
 		type User struct {
 			Name     *string
@@ -359,4 +398,558 @@ func TestRedact(t *testing.T) {
 			t.Errorf("Expected Password to remain nil")
 		}
 	})
+
+	t.Run("Map Of Any Holding Struct Pointer", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		user := &User{Name: "John", Password: "secret123"}
+		data := map[string]any{
+			"user":  user,
+			"users": []any{&User{Name: "Jane", Password: "pass456"}},
+			"plain": User{Name: "Bob", Password: "bobpass"},
+		}
+
+		result := Redact(data, isSensitive, redactValue)
+
+		redactedUser, ok := result["user"].(*User)
+		if !ok {
+			t.Fatalf("Expected user to remain a *User, got %T", result["user"])
+		}
+		if redactedUser.Password != "***REDACTED***" {
+			t.Errorf("Expected user.Password to be redacted, got %s", redactedUser.Password)
+		}
+		if redactedUser.Name != "John" {
+			t.Errorf("Expected user.Name to be 'John', got %s", redactedUser.Name)
+		}
+		if nested := result["users"].([]any)[0].(*User); nested.Password != "***REDACTED***" {
+			t.Errorf("Expected users[0].Password to be redacted, got %s", nested.Password)
+		}
+		if plain := result["plain"].(User); plain.Password != "***REDACTED***" {
+			t.Errorf("Expected plain.Password to be redacted, got %s", plain.Password)
+		}
+		if user.Password != "secret123" {
+			t.Errorf("Original user was modified")
+		}
+	})
+
+	t.Run("Slice Of Maps With Nested Maps", func(t *testing.T) {
+		type Config struct {
+			Items []map[string]any
+		}
+
+		config := Config{
+			Items: []map[string]any{
+				{"name": "a", "token": "tok-a"},
+				nil,
+				{
+					"name":  "b",
+					"token": "tok-b",
+					"nested": map[string]any{
+						"secret": "s",
+						"more":   []any{map[string]any{"password": "p", "port": 8080}},
+					},
+				},
+			},
+		}
+
+		result := Redact(config, isSensitive, redactValue)
+
+		if len(result.Items) != 3 {
+			t.Fatalf("Expected 3 items, got %d", len(result.Items))
+		}
+		if result.Items[1] != nil {
+			t.Errorf("Expected nil map element to survive, got %v", result.Items[1])
+		}
+		for _, i := range []int{0, 2} {
+			if result.Items[i]["token"] != "***REDACTED***" {
+				t.Errorf("Expected Items[%d].token to be redacted, got %v", i, result.Items[i]["token"])
+			}
+		}
+		if result.Items[0]["name"] != "a" {
+			t.Errorf("Expected Items[0].name to be 'a', got %v", result.Items[0]["name"])
+		}
+		nested := result.Items[2]["nested"].(map[string]any)
+		if nested["secret"] != "***REDACTED***" {
+			t.Errorf("Expected nested.secret to be redacted, got %v", nested["secret"])
+		}
+		deep := nested["more"].([]any)[0].(map[string]any)
+		if deep["password"] != "***REDACTED***" || deep["port"] != 8080 {
+			t.Errorf("Expected deeply nested map to be redacted, got %v", deep)
+		}
+		if config.Items[0]["token"] != "tok-a" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Top Level Heterogeneous Map", func(t *testing.T) {
+		data := map[string]any{
+			"password": "hunter2",
+			"count":    3,
+			"token":    42,
+			"profile":  map[string]any{"secret": "s", "name": "alice"},
+			"tags":     []string{"a", "b"},
+		}
+
+		result := Redact(data, isSensitive, redactValue)
+
+		if result["password"] != "***REDACTED***" {
+			t.Errorf("Expected password to be redacted, got %v", result["password"])
+		}
+		if result["count"] != 3 || result["token"] != 42 {
+			t.Errorf("Expected non-string values to be kept, got %v and %v", result["count"], result["token"])
+		}
+		profile, ok := result["profile"].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected profile to stay a map[string]any, got %T", result["profile"])
+		}
+		if profile["secret"] != "***REDACTED***" || profile["name"] != "alice" {
+			t.Errorf("Expected nested map to be redacted, got %v", profile)
+		}
+		if tags, ok := result["tags"].([]string); !ok || len(tags) != 2 {
+			t.Errorf("Expected tags to stay a []string, got %T", result["tags"])
+		}
+		if data["password"] != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Top Level Nil And Empty Maps", func(t *testing.T) {
+		var nilMap map[string]any
+		if result := Redact(nilMap, isSensitive, redactValue); result != nil {
+			t.Errorf("Expected nil map to stay nil, got %v", result)
+		}
+
+		empty := map[string]any{}
+		result := Redact(empty, isSensitive, redactValue)
+		if result == nil || len(result) != 0 {
+			t.Errorf("Expected empty non-nil map, got %v", result)
+		}
+
+		type Settings map[string]any
+		named := Redact(Settings{"password": "x"}, isSensitive, redactValue)
+		if named["password"] != "***REDACTED***" {
+			t.Errorf("Expected named map type to be redacted, got %v", named)
+		}
+	})
+
+	t.Run("Map Of String Slices", func(t *testing.T) {
+		form := map[string][]string{
+			"password": {"a", "b"},
+			"username": {"alice"},
+			"token":    nil,
+		}
+
+		result := Redact(form, isSensitive, redactValue)
+
+		if len(result["password"]) != 2 || result["password"][0] != "***REDACTED***" || result["password"][1] != "***REDACTED***" {
+			t.Errorf("Expected both password values to be redacted, got %v", result["password"])
+		}
+		if result["username"][0] != "alice" {
+			t.Errorf("Expected username to be kept, got %v", result["username"])
+		}
+		if result["token"] != nil {
+			t.Errorf("Expected nil token slice to stay nil, got %v", result["token"])
+		}
+		if form["password"][0] != "a" {
+			t.Errorf("Original was modified")
+		}
+
+		decoded := map[string]any{"secret": []any{"x", 1}}
+		redacted := Redact(decoded, isSensitive, redactValue)
+		if values := redacted["secret"].([]any); values[0] != "***REDACTED***" || values[1] != 1 {
+			t.Errorf("Expected string elements of []any to be redacted, got %v", values)
+		}
+	})
+
+	t.Run("Pointers To Containers", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		type Config struct {
+			Headers *map[string]string
+			Users   *[]User
+			Extra   *any
+			Secret  *map[string]string
+		}
+
+		headers := map[string]string{"token": "abc", "accept": "json"}
+		users := []User{{Name: "alice", Password: "p1"}, {Name: "bob", Password: "p2"}}
+		var extra any = map[string]any{"apikey": "k"}
+		config := Config{Headers: &headers, Users: &users, Extra: &extra, Secret: &map[string]string{"a": "b"}}
+
+		result := Redact(config, isSensitive, redactValue)
+
+		if result.Headers == &headers || (*result.Headers)["token"] != "***REDACTED***" || (*result.Headers)["accept"] != "json" {
+			t.Errorf("Expected a new redacted map behind Headers, got %v", *result.Headers)
+		}
+		if result.Users == &users || (*result.Users)[0].Password != "***REDACTED***" || (*result.Users)[1].Name != "bob" {
+			t.Errorf("Expected a new redacted slice behind Users, got %v", *result.Users)
+		}
+		if inner := (*result.Extra).(map[string]any); inner["apikey"] != "***REDACTED***" {
+			t.Errorf("Expected map behind *any to be redacted, got %v", inner)
+		}
+		if (*result.Secret)["a"] != "b" {
+			t.Errorf("Expected sensitive map with a mismatched redactValue result to be recursed, got %v", *result.Secret)
+		}
+		if headers["token"] != "abc" || users[0].Password != "p1" {
+			t.Errorf("Original was modified")
+		}
+
+		var nilMap map[string]string
+		var nilSlice []User
+		var nilAny any
+		result = Redact(Config{Headers: &nilMap, Users: &nilSlice, Extra: &nilAny}, isSensitive, redactValue)
+		if result.Headers == nil || *result.Headers != nil || result.Users == nil || *result.Users != nil {
+			t.Errorf("Expected pointers to nil containers to be kept, got %v %v", result.Headers, result.Users)
+		}
+		if result.Extra == nil || *result.Extra != nil {
+			t.Errorf("Expected pointer to nil interface to be kept, got %v", result.Extra)
+		}
+
+		result = Redact(Config{}, isSensitive, redactValue)
+		if result.Headers != nil || result.Users != nil || result.Extra != nil || result.Secret != nil {
+			t.Errorf("Expected nil pointers to stay nil, got %+v", result)
+		}
+	})
+
+	t.Run("Interface Field Replaced By Different Type", func(t *testing.T) {
+		type Credentials struct {
+			User string
+			Key  string
+		}
+
+		type Event struct {
+			Secret  any
+			Token   fmt.Stringer
+			Payload any
+		}
+
+		replace := func(v any) any {
+			if _, ok := v.(string); ok {
+				return "***REDACTED***"
+			}
+			return "[redacted]"
+		}
+
+		event := Event{
+			Secret:  Credentials{User: "admin", Key: "k"},
+			Token:   edgeCaseKey{Name: "t"},
+			Payload: map[string]any{"apikey": 42},
+		}
+
+		result := Redact(event, isSensitive, replace)
+
+		if result.Secret != "[redacted]" {
+			t.Errorf("Expected struct in any field to be replaced by a string, got %#v", result.Secret)
+		}
+		if result.Token != fmt.Stringer(edgeCaseKey{Name: "t"}) {
+			t.Errorf("Expected a non-empty interface to keep its type, got %#v", result.Token)
+		}
+		if payload := result.Payload.(map[string]any); payload["apikey"] != "[redacted]" {
+			t.Errorf("Expected int in map[string]any to be replaced by a string, got %#v", payload["apikey"])
+		}
+		if event.Secret != (Credentials{User: "admin", Key: "k"}) {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Interface Holding Pointer", func(t *testing.T) {
+		type Account struct {
+			User     string
+			Password string
+		}
+
+		type Envelope struct {
+			Body any
+			Meta map[string]any
+		}
+
+		account := &Account{User: "admin", Password: "hunter2"}
+		envelope := Envelope{Body: account, Meta: map[string]any{"account": account}}
+
+		result := Redact(envelope, isSensitive, redactValue)
+
+		body, ok := result.Body.(*Account)
+		if !ok {
+			t.Fatalf("Expected the any field to still hold a *Account, got %#v", result.Body)
+		}
+		if body.Password != "***REDACTED***" || body.User != "admin" {
+			t.Errorf("Expected Password to be redacted through the pointer, got %+v", body)
+		}
+		if body == account {
+			t.Errorf("Expected a copy of the pointed-to struct, not the original pointer")
+		}
+		if meta, ok := result.Meta["account"].(*Account); !ok || meta.Password != "***REDACTED***" {
+			t.Errorf("Expected the map value to still hold a redacted *Account, got %#v", result.Meta["account"])
+		}
+		if account.Password != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Mixed Slice Of Any", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		buffer := []any{
+			User{Name: "alice", Password: "p1"},
+			"plain",
+			map[string]string{"token": "abc", "region": "us"},
+			&User{Name: "bob", Password: "p2"},
+			"token=abc",
+			nil,
+		}
+
+		result := Redact(buffer, isSensitive, redactValue)
+
+		if result[0] != (User{Name: "alice", Password: "***REDACTED***"}) {
+			t.Errorf("Expected struct element to be redacted, got %#v", result[0])
+		}
+		if result[1] != "plain" || result[4] != "token=abc" {
+			t.Errorf("Expected standalone string elements to be kept, got %#v %#v", result[1], result[4])
+		}
+		if m := result[2].(map[string]string); m["token"] != "***REDACTED***" || m["region"] != "us" {
+			t.Errorf("Expected map element to be redacted, got %#v", m)
+		}
+		if u := result[3].(*User); *u != (User{Name: "bob", Password: "***REDACTED***"}) {
+			t.Errorf("Expected pointer element to be redacted, got %#v", u)
+		}
+		if result[5] != nil {
+			t.Errorf("Expected nil element to stay nil, got %#v", result[5])
+		}
+		if buffer[0].(User).Password != "p1" || buffer[2].(map[string]string)["token"] != "abc" {
+			t.Errorf("Original was modified")
+		}
+
+		// Standalone strings are only changed by value-based options
+		scrubbed := Redact(buffer, isSensitive, redactValue, WithScrubSubstrings("token=***", regexp.MustCompile(`token=\S+`)))
+		if scrubbed[4] != "token=***" || scrubbed[1] != "plain" {
+			t.Errorf("Expected string elements to be scrubbed, got %#v %#v", scrubbed[1], scrubbed[4])
+		}
+		byValue := RedactByValue(buffer, func(s string) bool { return s == "plain" }, redactValue)
+		if byValue[1] != "***REDACTED***" || byValue[0].(User).Password != "p1" {
+			t.Errorf("Expected only matching string elements to be redacted by value, got %#v", byValue)
+		}
+	})
+
+	t.Run("Map Of Pointers", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		users := map[string]*User{
+			"alice": {Name: "alice", Password: "p1"},
+			"bob":   nil,
+		}
+
+		result := Redact(users, isSensitive, redactValue)
+
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 entries, got %d", len(result))
+		}
+		if result["alice"] == users["alice"] || *result["alice"] != (User{Name: "alice", Password: "***REDACTED***"}) {
+			t.Errorf("Expected a new redacted user, got %+v", result["alice"])
+		}
+		if bob, ok := result["bob"]; !ok || bob != nil {
+			t.Errorf("Expected nil entry to be kept as nil, got %v (present: %v)", bob, ok)
+		}
+		if users["alice"].Password != "p1" {
+			t.Errorf("Original was modified")
+		}
+
+		// Pointers under sensitive keys are redacted through the pointer too
+		secrets := map[string]*string{"token": new(string), "password": nil}
+		*secrets["token"] = "abc"
+		redacted := Redact(secrets, isSensitive, redactValue)
+		if redacted["token"] == nil || *redacted["token"] != "***REDACTED***" {
+			t.Errorf("Expected *string under sensitive key to be redacted, got %v", redacted["token"])
+		}
+		if p, ok := redacted["password"]; !ok || p != nil {
+			t.Errorf("Expected nil *string under sensitive key to be kept, got %v (present: %v)", p, ok)
+		}
+		if *secrets["token"] != "abc" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Multidimensional Slices And Arrays", func(t *testing.T) {
+		isSecret := func(s string) bool {
+			return strings.HasPrefix(s, "sk_")
+		}
+
+		grid := [][]string{{"a", "sk_1"}, nil, {"sk_2"}}
+		result := RedactByValue(grid, isSecret, redactValue)
+		expectedGrid := [][]string{{"a", "***REDACTED***"}, nil, {"***REDACTED***"}}
+		if !reflect.DeepEqual(result, expectedGrid) {
+			t.Errorf("Expected %v, got %v", expectedGrid, result)
+		}
+		if grid[0][1] != "sk_1" {
+			t.Errorf("Original was modified")
+		}
+
+		board := [2][2]string{{"sk_1", "b"}, {"c", "sk_2"}}
+		expectedBoard := [2][2]string{{"***REDACTED***", "b"}, {"c", "***REDACTED***"}}
+		if got := RedactByValue(board, isSecret, redactValue); got != expectedBoard {
+			t.Errorf("Expected %v, got %v", expectedBoard, got)
+		}
+
+		cube := [][2][]string{{{"sk_1"}, {"x"}}}
+		expectedCube := [][2][]string{{{"***REDACTED***"}, {"x"}}}
+		if got := RedactByValue(cube, isSecret, redactValue); !reflect.DeepEqual(got, expectedCube) {
+			t.Errorf("Expected %v, got %v", expectedCube, got)
+		}
+
+		type Config struct {
+			Grid   [3][3]int
+			Tokens [][]string
+		}
+		config := Config{Grid: [3][3]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, Tokens: [][]string{{"t1"}}}
+		redacted := Redact(config, isSensitive, redactValue)
+		if redacted.Grid != config.Grid {
+			t.Errorf("Expected non-sensitive grid to be copied, got %v", redacted.Grid)
+		}
+		if redacted.Tokens[0][0] != "t1" || &redacted.Tokens[0][0] == &config.Tokens[0][0] {
+			t.Errorf("Expected nested slices to be copied into new backing arrays, got %v", redacted.Tokens)
+		}
+	})
+}
+
+func TestRedactAny(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	t.Run("Struct Boxed In Any", func(t *testing.T) {
+		type User struct {
+			Name     string
+			Password string
+		}
+
+		var boxed any = User{Name: "John", Password: "secret123"}
+
+		result := RedactAny(boxed, isSensitive, redactValue)
+
+		user, ok := result.(User)
+		if !ok {
+			t.Fatalf("Expected result to be a User, got %T", result)
+		}
+		if user.Name != "John" {
+			t.Errorf("Expected Name to be 'John', got %s", user.Name)
+		}
+		if user.Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted, got %s", user.Password)
+		}
+	})
+
+	t.Run("Nil Input", func(t *testing.T) {
+		if result := RedactAny(nil, isSensitive, redactValue); result != nil {
+			t.Errorf("Expected nil result, got %v", result)
+		}
+	})
+}
+
+func TestRedactIf(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type User struct {
+		Name     string
+		Password string
+	}
+
+	user := &User{Name: "alice", Password: "hunter2"}
+
+	t.Run("Enabled", func(t *testing.T) {
+		result := RedactIf(true, user, isSensitive, redactValue)
+
+		if result.Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted, got %s", result.Password)
+		}
+		if user.Password != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		result := RedactIf(false, user, isSensitive, redactValue)
+
+		if result != user {
+			t.Errorf("Expected the argument itself to be returned")
+		}
+		if result.Password != "hunter2" || result.Name != "alice" {
+			t.Errorf("Expected values to be unchanged, got %+v", result)
+		}
+	})
+}
+
+// BenchmarkRedactNoSensitiveFields tracks the allocations of redacting a
+// value without sensitive fields. Redact always returns a deep copy, so the
+// count is not zero: it is the baseline to compare against when changing
+// how values are copied.
+func BenchmarkRedactNoSensitiveFields(b *testing.B) {
+	type Address struct {
+		Street string
+		City   string
+	}
+	type Profile struct {
+		Name    string
+		Age     int
+		Tags    []string
+		Address *Address
+	}
+
+	isSensitive := func(name string) bool { return name == "Password" }
+	redactValue := func(v any) any { return "***REDACTED***" }
+	profile := Profile{Name: "alice", Age: 30, Tags: []string{"a", "b"}, Address: &Address{Street: "1 Main St", City: "Springfield"}}
+	r := NewRedactor(isSensitive, redactValue)
+
+	b.Run("Redact", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Redact(profile, isSensitive, redactValue)
+		}
+	})
+
+	b.Run("Redactor", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			RedactWith(r, profile)
+		}
+	})
+
+	b.Run("Bytes", func(b *testing.B) {
+		type Upload struct {
+			Name string
+			Data []byte
+		}
+		upload := Upload{Name: "file", Data: make([]byte, 1<<20)}
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Redact(upload, isSensitive, redactValue)
+		}
+	})
 }
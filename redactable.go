@@ -0,0 +1,59 @@
+package yaredact
+
+import "reflect"
+
+// Redactable is implemented by struct types that declare their own sensitive
+// fields, keeping the policy next to the type definition. The returned Go
+// field names are redacted in addition to those matched by isSensitive.
+//
+// SensitiveFields is called once per type on a zero value, so it must return
+// the same names regardless of the receiver's contents.
+type Redactable interface {
+	SensitiveFields() []string
+}
+
+var redactableType = reflect.TypeOf((*Redactable)(nil)).Elem()
+
+// declaredSensitiveFields returns the field names declared sensitive by a
+// struct type implementing Redactable, with a value or pointer receiver
+func declaredSensitiveFields(t reflect.Type) map[string]bool {
+	var r Redactable
+	switch {
+	case t.Implements(redactableType):
+		r = reflect.Zero(t).Interface().(Redactable)
+	case reflect.PointerTo(t).Implements(redactableType):
+		r = reflect.New(t).Interface().(Redactable)
+	default:
+		return nil
+	}
+
+	declared := map[string]bool{}
+	for _, name := range r.SensitiveFields() {
+		declared[name] = true
+	}
+	return declared
+}
+
+// NoRedact is implemented by types that are safe to log in full, the
+// inverse of Redactable. Their values are shared as-is, like types excluded
+// with WithIgnoreTypes: never walked, copied or passed to redactValue, even
+// under a sensitive name.
+type NoRedact interface {
+	NoRedact()
+}
+
+var noRedactType = reflect.TypeOf((*NoRedact)(nil)).Elem()
+
+// isNoRedact reports whether values of type t implement NoRedact, with a
+// value or pointer receiver. Interface types are decided by their contents.
+func isNoRedact(t reflect.Type) bool {
+	return traitsOf(t)&traitNoRedact != 0
+}
+
+// implementsNoRedact computes isNoRedact for traitsOf
+func implementsNoRedact(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	return t.Implements(noRedactType) || reflect.PointerTo(t).Implements(noRedactType)
+}
@@ -0,0 +1,118 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+// redactableAccount declares its own sensitive fields
+type redactableAccount struct {
+	Name   string
+	Pin    string
+	Answer string
+	Email  string
+}
+
+func (redactableAccount) SensitiveFields() []string {
+	return []string{"Pin", "Answer"}
+}
+
+// redactablePointerAccount declares its policy with a pointer receiver
+type redactablePointerAccount struct {
+	Name string
+	Pin  string
+}
+
+func (*redactablePointerAccount) SensitiveFields() []string {
+	return []string{"Pin"}
+}
+
+func TestRedactable(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "email"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	t.Run("Declared Fields", func(t *testing.T) {
+		account := redactableAccount{Name: "alice", Pin: "1234", Answer: "rex", Email: "a@example.com"}
+
+		result := Redact(account, isSensitive, redactValue)
+
+		if result.Pin != "***REDACTED***" || result.Answer != "***REDACTED***" {
+			t.Errorf("Expected declared fields to be redacted, got %+v", result)
+		}
+		if result.Email != "***REDACTED***" {
+			t.Errorf("Expected isSensitive to still apply, got %s", result.Email)
+		}
+		if result.Name != "alice" {
+			t.Errorf("Expected Name to be kept, got %s", result.Name)
+		}
+	})
+
+	t.Run("Pointer Receiver In Slice", func(t *testing.T) {
+		accounts := []redactablePointerAccount{{Name: "alice", Pin: "1234"}}
+
+		result := Redact(accounts, isSensitive, redactValue)
+
+		if result[0].Pin != "***REDACTED***" || result[0].Name != "alice" {
+			t.Errorf("Expected only Pin to be redacted, got %+v", result[0])
+		}
+	})
+}
+
+// publicKey is safe to log in full, even though its field is named Secret
+type publicKey struct {
+	Secret string
+}
+
+func (publicKey) NoRedact() {}
+
+func TestNoRedact(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "secret" || lower == "key"
+	}
+
+	redactValue := func(any) any {
+		return "***REDACTED***"
+	}
+
+	type Service struct {
+		Public  publicKey
+		Key     publicKey
+		Pointer *publicKey
+		Boxed   any
+		Secret  string
+	}
+
+	service := Service{
+		Public:  publicKey{Secret: "pk1"},
+		Key:     publicKey{Secret: "pk2"},
+		Pointer: &publicKey{Secret: "pk3"},
+		Boxed:   publicKey{Secret: "pk4"},
+		Secret:  "s",
+	}
+
+	result := Redact(service, isSensitive, redactValue)
+
+	if result.Public.Secret != "pk1" || result.Pointer.Secret != "pk3" || result.Boxed.(publicKey).Secret != "pk4" {
+		t.Errorf("Expected NoRedact values to pass through, got %+v", result)
+	}
+	if result.Key.Secret != "pk2" {
+		t.Errorf("Expected NoRedact value under a sensitive name to pass through, got %+v", result.Key)
+	}
+	if result.Secret != "***REDACTED***" {
+		t.Errorf("Expected other fields to still be redacted, got %s", result.Secret)
+	}
+
+	keys := Redact(map[string]publicKey{"key": {Secret: "pk5"}}, isSensitive, redactValue)
+	if keys["key"].Secret != "pk5" {
+		t.Errorf("Expected NoRedact map value under a sensitive key to pass through, got %+v", keys["key"])
+	}
+}
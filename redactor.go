@@ -0,0 +1,183 @@
+package yaredact
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// maxCachedTypes bounds the number of struct types kept in a field cache.
+// When the limit is reached the cache is cleared and repopulated on demand.
+const maxCachedTypes = 1024
+
+// Redactor is a reusable redaction policy. It caches per-type struct field
+// metadata, so reusing a Redactor across calls avoids re-evaluating
+// isSensitive for every field of every value. A Redactor is safe for
+// concurrent use as long as its callbacks are.
+type Redactor struct {
+	config *config
+}
+
+// NewRedactor creates a Redactor with the given predicates and options
+func NewRedactor(isSensitive func(string) bool, redactValue func(any) any, opts ...Option) *Redactor {
+	c := newConfig(isSensitive, redactValue, opts)
+	c.fields = newFieldCache()
+	// Give each policy a cache of its own too
+	c.buildPolicies()
+	return &Redactor{config: c}
+}
+
+// Redact redacts arg, returning the redacted value boxed in an `any`.
+// A nil input returns nil. Use RedactWith to keep the static type.
+func (r *Redactor) Redact(arg any) any {
+	if arg == nil {
+		return nil
+	}
+	w := newWalker(r.config)
+	return w.redactRoot(reflect.ValueOf(arg)).Interface()
+}
+
+// RedactWith is like Redact but uses the policy and cache of r
+func RedactWith[T any](r *Redactor, arg T) T {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero
+	}
+	w := newWalker(r.config)
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	return result.(T)
+}
+
+// Clone returns an independent Redactor with the same policy as r and opts
+// applied on top, e.g. WithRedactValue for a stricter mask. The clone shares
+// r's struct field cache unless opts change how fields are matched
+// (WithTagMatch, WithTagNames, WithRedactTag or WithNormalizeName).
+func (r *Redactor) Clone(opts ...Option) *Redactor {
+	c := *r.config
+	c.fieldOptions = 0
+	c.apply(opts)
+	if c.fieldOptions > 0 {
+		c.fields = newFieldCache()
+	}
+	return &Redactor{config: &c}
+}
+
+// ResetCache clears the cached struct field metadata. The cache is bounded,
+// so this is only needed to release memory early, e.g. in long-running
+// processes that generate many struct types dynamically (plugins, codegen)
+// and no longer redact them.
+func (r *Redactor) ResetCache() {
+	r.config.fields.reset()
+}
+
+// fieldCache maps struct types to the sensitivity of each of their fields
+type fieldCache struct {
+	mu      sync.RWMutex
+	entries map[reflect.Type][]bool
+}
+
+func newFieldCache() *fieldCache {
+	return &fieldCache{entries: map[reflect.Type][]bool{}}
+}
+
+func (fc *fieldCache) get(t reflect.Type) ([]bool, bool) {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	sensitive, ok := fc.entries[t]
+	return sensitive, ok
+}
+
+func (fc *fieldCache) put(t reflect.Type, sensitive []bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if len(fc.entries) >= maxCachedTypes {
+		fc.entries = map[reflect.Type][]bool{}
+	}
+	fc.entries[t] = sensitive
+}
+
+func (fc *fieldCache) reset() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.entries = map[reflect.Type][]bool{}
+}
+
+func (fc *fieldCache) len() int {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	return len(fc.entries)
+}
+
+// sensitiveFields reports, for each field of struct type t, whether it is
+// sensitive by directive tag, name, tags, declaration index or the type's
+// Redactable policy. Only a Redactor's config has a field cache; other
+// configs compute the fields on every call.
+func (c *config) sensitiveFields(t reflect.Type) []bool {
+	if c.fields == nil {
+		return c.computeSensitiveFields(t)
+	}
+	if sensitive, ok := c.fields.get(t); ok {
+		return sensitive
+	}
+	sensitive := c.computeSensitiveFields(t)
+	c.fields.put(t, sensitive)
+	return sensitive
+}
+
+// sensitiveFields is like config.sensitiveFields, but without a Redactor's
+// field cache it remembers the fields of each struct type for the duration
+// of the walk, without locking. Walks usually see few struct types, so they
+// are looked up in a slice, backed by the walker for the first few.
+func (w *walker) sensitiveFields(t reflect.Type) []bool {
+	if w.fields != nil {
+		return w.config.sensitiveFields(t)
+	}
+	for _, known := range w.walkFields {
+		if known.typ == t && known.config == w.config {
+			return known.sensitive
+		}
+	}
+	if w.walkFields == nil {
+		w.walkFields = w.walkFieldsBuf[:0]
+	}
+	sensitive := w.computeSensitiveFields(t)
+	w.walkFields = append(w.walkFields, walkerFields{config: w.config, typ: t, sensitive: sensitive})
+	return sensitive
+}
+
+// walkerFields holds the sensitive fields of a struct type under a config,
+// which differs below structs declaring a policy
+type walkerFields struct {
+	config    *config
+	typ       reflect.Type
+	sensitive []bool
+}
+
+// computeSensitiveFields computes sensitiveFields without a cache
+func (c *config) computeSensitiveFields(t reflect.Type) []bool {
+	declared := declaredSensitiveFields(t)
+	sensitive := make([]bool, t.NumField())
+	for i := range sensitive {
+		field := t.Field(i)
+		if directive, ok := c.redactDirective(field); ok {
+			sensitive[i] = directive
+			continue
+		}
+		sensitive[i] = c.fieldIndices[i] || declared[field.Name] || c.isFieldSensitive(field)
+	}
+	return sensitive
+}
+
+// redactDirective reports the sensitivity set by the WithRedactTag directive
+// on field, and whether the field has a valid directive
+func (c *config) redactDirective(field reflect.StructField) (sensitive, ok bool) {
+	if c.redactTag == "" {
+		return false, false
+	}
+	value, ok := field.Tag.Lookup(c.redactTag)
+	if !ok {
+		return false, false
+	}
+	sensitive, err := strconv.ParseBool(value)
+	return sensitive, err == nil
+}
@@ -0,0 +1,113 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type User struct {
+		Name     string
+		Password string
+	}
+
+	type Admin struct {
+		User  User
+		Level int
+	}
+
+	t.Run("Reusable Redactor", func(t *testing.T) {
+		r := NewRedactor(isSensitive, redactValue)
+
+		for i := 0; i < 2; i++ {
+			result := RedactWith(r, User{Name: "John", Password: "secret123"})
+			if result.Password != "***REDACTED***" {
+				t.Errorf("Expected Password to be redacted, got %s", result.Password)
+			}
+		}
+
+		boxed := r.Redact(User{Name: "John", Password: "secret123"})
+		if boxed.(User).Password != "***REDACTED***" {
+			t.Errorf("Expected boxed Password to be redacted, got %s", boxed.(User).Password)
+		}
+	})
+
+	t.Run("ResetCache", func(t *testing.T) {
+		r := NewRedactor(isSensitive, redactValue)
+
+		RedactWith(r, Admin{User: User{Name: "John", Password: "secret123"}, Level: 1})
+		if n := r.config.fields.len(); n != 2 {
+			t.Errorf("Expected 2 cached types, got %d", n)
+		}
+
+		r.ResetCache()
+		if n := r.config.fields.len(); n != 0 {
+			t.Errorf("Expected empty cache after reset, got %d", n)
+		}
+
+		result := RedactWith(r, Admin{User: User{Name: "John", Password: "secret123"}, Level: 1})
+		if result.User.Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted after reset, got %s", result.User.Password)
+		}
+		if n := r.config.fields.len(); n != 2 {
+			t.Errorf("Expected cache to be repopulated, got %d", n)
+		}
+	})
+
+	t.Run("Bounded Cache", func(t *testing.T) {
+		fc := newFieldCache()
+		for i := 0; i <= maxCachedTypes; i++ {
+			// Distinct array types stand in for distinct struct types
+			fc.put(reflect.ArrayOf(i, reflect.TypeOf("")), nil)
+		}
+		if n := fc.len(); n > maxCachedTypes {
+			t.Errorf("Expected cache to stay within %d entries, got %d", maxCachedTypes, n)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		r := NewRedactor(isSensitive, redactValue, WithTagNames("json"))
+		RedactWith(r, User{Name: "John", Password: "secret123"})
+
+		strict := r.Clone(WithRedactValue(MaskFixed(3, '#')))
+		if strict.config.fields != r.config.fields {
+			t.Errorf("Expected clone to share the field cache")
+		}
+
+		result := RedactWith(strict, User{Name: "John", Password: "secret123"})
+		if result.Password != "###" {
+			t.Errorf("Expected clone to use its own redactValue, got %s", result.Password)
+		}
+
+		original := RedactWith(r, User{Name: "John", Password: "secret123"})
+		if original.Password != "***REDACTED***" {
+			t.Errorf("Expected original to be unaffected, got %s", original.Password)
+		}
+
+		type Login struct {
+			Passwd string `db:"PASSWORD"`
+		}
+		normalized := r.Clone(WithTagNames("db"), WithNormalizeName(strings.ToLower))
+		if normalized.config.fields == r.config.fields {
+			t.Errorf("Expected clone with different field matching to get its own cache")
+		}
+		if got := RedactWith(normalized, Login{Passwd: "x"}); got.Passwd != "***REDACTED***" {
+			t.Errorf("Expected clone to match the normalized db tag, got %s", got.Passwd)
+		}
+		if got := RedactWith(r, Login{Passwd: "x"}); got.Passwd != "x" {
+			t.Errorf("Expected original tag names to be unaffected, got %s", got.Passwd)
+		}
+	})
+}
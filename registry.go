@@ -0,0 +1,38 @@
+package yaredact
+
+import "sync"
+
+// registry is the process-wide set of names added with RegisterSensitive
+var registry = struct {
+	sync.RWMutex
+	names map[string]struct{}
+}{names: map[string]struct{}{}}
+
+// RegisterSensitive adds names to the global registry of sensitive field
+// names, typically from a package's init function so each package can
+// contribute its own secrets to a shared policy. It is safe for concurrent use.
+//
+// A Redactor caches field sensitivity per struct type; call ResetCache after
+// registering names once it has been used.
+func RegisterSensitive(names ...string) {
+	registry.Lock()
+	defer registry.Unlock()
+	for _, name := range names {
+		registry.names[name] = struct{}{}
+	}
+}
+
+// IsRegisteredSensitive reports whether name was added with RegisterSensitive.
+// Names are matched exactly; combine with WithNormalizeName to ignore case.
+func IsRegisteredSensitive(name string) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+	_, ok := registry.names[name]
+	return ok
+}
+
+// RegisteredSensitive returns an isSensitive predicate backed by the global
+// registry. Names registered later are picked up by the same predicate.
+func RegisteredSensitive() func(string) bool {
+	return IsRegisteredSensitive
+}
@@ -0,0 +1,67 @@
+package yaredact
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRegisterSensitive(t *testing.T) {
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	t.Run("Registered Names Are Redacted", func(t *testing.T) {
+		RegisterSensitive("registryTestPin", "registryTestOtp")
+
+		type Login struct {
+			RegistryTestPin string
+			Pin             string `json:"registryTestPin"`
+			Otp             string `json:"registryTestOtp"`
+			Name            string
+		}
+
+		result := Redact(Login{RegistryTestPin: "1", Pin: "2", Otp: "3", Name: "alice"}, RegisteredSensitive(), redactValue)
+
+		if result.RegistryTestPin != "1" {
+			t.Errorf("Expected names to match exactly, got %s", result.RegistryTestPin)
+		}
+		if result.Pin != "***REDACTED***" || result.Otp != "***REDACTED***" {
+			t.Errorf("Expected registered tag names to be redacted, got %+v", result)
+		}
+		if result.Name != "alice" {
+			t.Errorf("Expected Name to be kept, got %s", result.Name)
+		}
+	})
+
+	t.Run("Concurrent Registration", func(t *testing.T) {
+		isSensitive := RegisteredSensitive()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := "registryConcurrent" + strconv.Itoa(i)
+				RegisterSensitive(name)
+				if !isSensitive(name) {
+					t.Errorf("Expected %s to be registered", name)
+				}
+				_ = IsRegisteredSensitive("registryConcurrentMissing")
+			}(i)
+		}
+		wg.Wait()
+
+		for i := 0; i < 50; i++ {
+			if !IsRegisteredSensitive("registryConcurrent" + strconv.Itoa(i)) {
+				t.Errorf("Expected registryConcurrent%d to be registered", i)
+			}
+		}
+		if IsRegisteredSensitive("registryConcurrentMissing") {
+			t.Errorf("Expected unregistered name not to match")
+		}
+	})
+}
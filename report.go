@@ -0,0 +1,29 @@
+package yaredact
+
+import "reflect"
+
+// RedactionRecord describes a single value replaced during redaction
+type RedactionRecord struct {
+	// Path is the dotted path to the value, e.g. "Users[0].Password"
+	Path string
+	// FieldName is the struct field name or map key that was found sensitive.
+	// It is empty when the value was redacted by a type handler.
+	FieldName string
+	// Kind is the kind of the value's static type before redaction
+	Kind reflect.Kind
+}
+
+// RedactWithReport is like Redact but also returns a record for every value
+// that was redacted, in traversal order. It is useful for verifying that a
+// policy masks exactly the intended fields in tests and audits.
+func RedactWithReport[T any](arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) (T, []RedactionRecord) {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero, nil
+	}
+
+	w := newWalker(newConfig(isSensitive, redactValue, opts))
+	w.report = true
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	return result.(T), w.records
+}
@@ -0,0 +1,62 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactWithReport(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Credentials struct {
+		Token string
+	}
+
+	type User struct {
+		Name     string
+		Password *string
+		Cred     Credentials
+	}
+
+	type Team struct {
+		Users    []User
+		Settings map[string]string
+	}
+
+	pass := "secret123"
+	team := Team{
+		Users: []User{
+			{Name: "Alice", Password: &pass, Cred: Credentials{Token: "abc"}},
+			{Name: "Bob", Cred: Credentials{Token: "def"}},
+		},
+		Settings: map[string]string{"token": "xyz", "theme": "dark"},
+	}
+
+	result, records := RedactWithReport(team, isSensitive, redactValue)
+
+	if result.Users[0].Cred.Token != "***REDACTED***" {
+		t.Errorf("Expected Users[0].Cred.Token to be redacted, got %s", result.Users[0].Cred.Token)
+	}
+
+	expected := []RedactionRecord{
+		{Path: "Users[0].Password", FieldName: "Password", Kind: reflect.String},
+		{Path: "Users[0].Cred.Token", FieldName: "Token", Kind: reflect.String},
+		{Path: "Users[1].Password", FieldName: "Password", Kind: reflect.Ptr}, // nil pointer is still passed to redactValue
+		{Path: "Users[1].Cred.Token", FieldName: "Token", Kind: reflect.String},
+		{Path: "Settings.token", FieldName: "token", Kind: reflect.String},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected records %+v, got %+v", expected, records)
+	}
+}
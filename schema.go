@@ -0,0 +1,51 @@
+package yaredact
+
+import "reflect"
+
+// SensitivePaths lists the paths of every struct field of type t that would
+// be considered sensitive, by name, tags or options, independently of any
+// value, e.g. to audit which fields a policy covers. Nested struct types are
+// recursed into through pointers, slices and arrays ("Users[*].Password")
+// and map values ("Accounts.*.Password"). Unexported fields are skipped, and
+// recursive types are only expanded once along each path.
+func SensitivePaths(t reflect.Type, isSensitive func(string) bool, opts ...Option) []string {
+	c := newConfig(isSensitive, nil, opts)
+	var paths []string
+	c.collectSensitivePaths(t, "", map[reflect.Type]bool{}, &paths)
+	return paths
+}
+
+// collectSensitivePaths appends the sensitive field paths under type t at
+// path to paths. visiting holds the struct types being expanded above path.
+func (c *config) collectSensitivePaths(t reflect.Type, path string, visiting map[reflect.Type]bool, paths *[]string) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		c.collectSensitivePaths(t.Elem(), path, visiting, paths)
+	case reflect.Slice, reflect.Array:
+		if isTextType(t) {
+			return
+		}
+		c.collectSensitivePaths(t.Elem(), path+"[*]", visiting, paths)
+	case reflect.Map:
+		c.collectSensitivePaths(t.Elem(), joinPath(path, "*"), visiting, paths)
+	case reflect.Struct:
+		if visiting[t] || isTextType(t) || isBigType(t) || t == syncMapType {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		sensitive := c.sensitiveFields(t)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !c.includeUnexported {
+				continue
+			}
+			fieldPath := joinPath(path, field.Name)
+			if sensitive[i] {
+				*paths = append(*paths, fieldPath)
+			}
+			c.collectSensitivePaths(field.Type, fieldPath, visiting, paths)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type schemaNode struct {
+	Name     string
+	Secret   string
+	Children []*schemaNode
+	Parent   *schemaNode
+}
+
+func TestSensitivePaths(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "secret" || lower == "token"
+	}
+
+	t.Run("Nested", func(t *testing.T) {
+		type Credentials struct {
+			User     string
+			Password string
+		}
+		type Account struct {
+			Name        string
+			Credentials *Credentials
+			Hint        string `json:"token,omitempty"`
+			password    string
+		}
+		type Config struct {
+			Primary  Account
+			Replicas []Account
+			ByRegion map[string]Account
+			Secret   Credentials
+		}
+
+		paths := SensitivePaths(reflect.TypeOf(Config{}), isSensitive)
+
+		expected := []string{
+			"Primary.Credentials.Password",
+			"Primary.Hint",
+			"Replicas[*].Credentials.Password",
+			"Replicas[*].Hint",
+			"ByRegion.*.Credentials.Password",
+			"ByRegion.*.Hint",
+			"Secret",
+			"Secret.Password",
+		}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("RecursiveType", func(t *testing.T) {
+		paths := SensitivePaths(reflect.TypeOf(schemaNode{}), isSensitive)
+
+		expected := []string{"Secret"}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		type Record struct {
+			Field string `custom:"secret"`
+		}
+
+		paths := SensitivePaths(reflect.TypeOf(&Record{}), isSensitive, WithTagNames("custom"))
+
+		if !reflect.DeepEqual(paths, []string{"Field"}) {
+			t.Errorf("Expected [Field], got %v", paths)
+		}
+	})
+}
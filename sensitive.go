@@ -0,0 +1,62 @@
+package yaredact
+
+import (
+	"strings"
+	"unicode"
+)
+
+// AnySensitive combines isSensitive predicates with OR semantics: a name is
+// sensitive if any predicate matches. Evaluation stops at the first match.
+func AnySensitive(preds ...func(string) bool) func(string) bool {
+	return func(name string) bool {
+		for _, pred := range preds {
+			if pred(name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllSensitive combines isSensitive predicates with AND semantics: a name is
+// sensitive only if every predicate matches. Evaluation stops at the first
+// mismatch. With no predicates nothing is sensitive.
+func AllSensitive(preds ...func(string) bool) func(string) bool {
+	return func(name string) bool {
+		if len(preds) == 0 {
+			return false
+		}
+		for _, pred := range preds {
+			if !pred(name) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SnakeCaseNormalize converts a name to lower snake case so different
+// spellings collapse to one, e.g. "APIKey", "apiKey", "api-key" and "api_key"
+// all become "api_key". It is intended for use with WithNormalizeName.
+func SnakeCaseNormalize(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '-' || r == '.' || r == ' ' {
+			r = '_'
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Word boundary: "apiKey" or the end of an acronym as in "APIKey"
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		if r == '_' && strings.HasSuffix(b.String(), "_") {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSensitiveCombinators(t *testing.T) {
+	calls := 0
+	isPassword := func(name string) bool {
+		calls++
+		return strings.EqualFold(name, "password")
+	}
+	hasToken := func(name string) bool {
+		calls++
+		return strings.Contains(strings.ToLower(name), "token")
+	}
+	isAccess := func(name string) bool {
+		calls++
+		return strings.HasPrefix(strings.ToLower(name), "access")
+	}
+
+	t.Run("AnySensitive", func(t *testing.T) {
+		isSensitive := AnySensitive(isPassword, hasToken)
+
+		tests := map[string]bool{
+			"Password":    true,
+			"AccessToken": true,
+			"Name":        false,
+		}
+		for name, expected := range tests {
+			if got := isSensitive(name); got != expected {
+				t.Errorf("Expected %s to be %v, got %v", name, expected, got)
+			}
+		}
+
+		calls = 0
+		isSensitive("password")
+		if calls != 1 {
+			t.Errorf("Expected evaluation to stop at first match, got %d calls", calls)
+		}
+	})
+
+	t.Run("AllSensitive", func(t *testing.T) {
+		isSensitive := AllSensitive(isAccess, hasToken)
+
+		tests := map[string]bool{
+			"AccessToken":  true,
+			"RefreshToken": false,
+			"AccessKey":    false,
+		}
+		for name, expected := range tests {
+			if got := isSensitive(name); got != expected {
+				t.Errorf("Expected %s to be %v, got %v", name, expected, got)
+			}
+		}
+
+		calls = 0
+		isSensitive("RefreshToken")
+		if calls != 1 {
+			t.Errorf("Expected evaluation to stop at first mismatch, got %d calls", calls)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if AnySensitive()("password") || AllSensitive()("password") {
+			t.Errorf("Expected empty combinators to match nothing")
+		}
+	})
+}
+
+func TestSnakeCaseNormalize(t *testing.T) {
+	tests := map[string]string{
+		"APIKey":       "api_key",
+		"apiKey":       "api_key",
+		"api_key":      "api_key",
+		"api-key":      "api_key",
+		"Password":     "password",
+		"AccessToken":  "access_token",
+		"OAuth2Token":  "o_auth2_token",
+		"user.id":      "user_id",
+		"HTTPServer":   "http_server",
+		"already__two": "already_two",
+	}
+
+	for input, expected := range tests {
+		if got := SnakeCaseNormalize(input); got != expected {
+			t.Errorf("SnakeCaseNormalize(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
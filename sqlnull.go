@@ -0,0 +1,40 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+)
+
+// isNullType reports whether t is one of the database/sql nullable types,
+// sql.NullString, sql.NullInt64, sql.Null[T] and so on: a struct holding a
+// value followed by a Valid flag
+func isNullType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") {
+		return false
+	}
+	if t.NumField() != 2 {
+		return false
+	}
+	valid := t.Field(1)
+	return valid.Name == "Valid" && valid.Type.Kind() == reflect.Bool
+}
+
+// redactNull redacts the sensitive sql.Null* value v at path. Only the inner
+// value of a valid (non-NULL) v is passed to redactValue and Valid is kept;
+// NULLs are copied as-is. A dropped inner value becomes NULL.
+func (w *walker) redactNull(v reflect.Value, path string) (reflect.Value, bool) {
+	result := reflect.New(v.Type()).Elem()
+	result.Set(v)
+	if !v.Field(1).Bool() {
+		return result, true
+	}
+	redacted, ok := w.redactSensitive(v.Field(0), path)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	if !redacted.IsValid() {
+		return reflect.Zero(v.Type()), true
+	}
+	result.Field(0).Set(redacted)
+	return result, true
+}
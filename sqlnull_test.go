@@ -0,0 +1,97 @@
+package yaredact
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactSQLNullTypes(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "pin" || lower == "birthday" || lower == "token"
+	}
+
+	redactValue := func(v any) any {
+		switch v.(type) {
+		case string:
+			return "***REDACTED***"
+		case int64:
+			return int64(0)
+		case time.Time:
+			return time.Time{}
+		}
+		return v
+	}
+
+	type Account struct {
+		Name     sql.NullString
+		Password sql.NullString
+		PIN      sql.NullInt64
+		Birthday sql.NullTime
+		Token    sql.Null[string]
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		account := Account{
+			Name:     sql.NullString{String: "alice", Valid: true},
+			Password: sql.NullString{String: "hunter2", Valid: true},
+			PIN:      sql.NullInt64{Int64: 1234, Valid: true},
+			Birthday: sql.NullTime{Time: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+			Token:    sql.Null[string]{V: "abc", Valid: true},
+		}
+
+		result := Redact(account, isSensitive, redactValue)
+
+		if result.Password != (sql.NullString{String: "***REDACTED***", Valid: true}) {
+			t.Errorf("Expected valid Password to be masked, got %+v", result.Password)
+		}
+		if result.PIN != (sql.NullInt64{Int64: 0, Valid: true}) {
+			t.Errorf("Expected valid PIN to be masked, got %+v", result.PIN)
+		}
+		if !result.Birthday.Time.IsZero() || !result.Birthday.Valid {
+			t.Errorf("Expected valid Birthday to be masked, got %+v", result.Birthday)
+		}
+		if result.Token != (sql.Null[string]{V: "***REDACTED***", Valid: true}) {
+			t.Errorf("Expected valid generic Token to be masked, got %+v", result.Token)
+		}
+		if result.Name != account.Name {
+			t.Errorf("Expected Name to be kept, got %+v", result.Name)
+		}
+		if account.Password.String != "hunter2" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// The inner value of a NULL is left alone, even if it isn't empty
+		account := Account{Password: sql.NullString{String: "stale"}}
+
+		result := Redact(account, isSensitive, redactValue)
+
+		if result.Password != (sql.NullString{String: "stale"}) {
+			t.Errorf("Expected NULL Password to be left alone, got %+v", result.Password)
+		}
+	})
+
+	t.Run("ScalarsOnly", func(t *testing.T) {
+		account := Account{Password: sql.NullString{String: "hunter2", Valid: true}}
+
+		result := Redact(account, isSensitive, redactValue, WithScalarsOnly())
+
+		if result.Password != (sql.NullString{String: "***REDACTED***", Valid: true}) {
+			t.Errorf("Expected sql.NullString to be treated as a scalar, got %+v", result.Password)
+		}
+	})
+
+	t.Run("MapValue", func(t *testing.T) {
+		row := map[string]sql.NullString{"password": {String: "hunter2", Valid: true}}
+
+		result := Redact(row, isSensitive, redactValue)
+
+		if result["password"] != (sql.NullString{String: "***REDACTED***", Valid: true}) {
+			t.Errorf("Expected map value to be masked, got %+v", result["password"])
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package yaredact
+
+import "reflect"
+
+// UnexportedFieldError is returned by RedactStrict when a struct has an
+// unexported field that can't be copied
+type UnexportedFieldError struct {
+	// Path is the path of the field, e.g. "Users[0].password"
+	Path string
+	// Type is the struct type containing the field
+	Type reflect.Type
+}
+
+func (e *UnexportedFieldError) Error() string {
+	return "yaredact: unexported field " + e.Path + " of " + e.Type.String() + " would be dropped"
+}
+
+// RedactStrict is like Redact but fails fast instead of silently zeroing
+// unexported struct fields, which reflection can't copy. It returns an
+// *UnexportedFieldError for the first one encountered. With WithUnexported
+// such fields are copied and no error is returned.
+func RedactStrict[T any](arg T, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) (T, error) {
+	var zero T
+	if reflect.ValueOf(arg).Kind() == reflect.Invalid {
+		return zero, nil
+	}
+
+	w := newWalker(newConfig(isSensitive, redactValue, opts))
+	w.strict = true
+	result := w.redactRoot(reflect.ValueOf(arg)).Interface()
+	if w.err != nil {
+		return zero, w.err
+	}
+	return result.(T), nil
+}
@@ -0,0 +1,80 @@
+package yaredact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactStrict(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type Session struct {
+		Password string
+		token    string
+	}
+
+	type Account struct {
+		Name     string
+		Sessions []Session
+	}
+
+	account := Account{Name: "alice", Sessions: []Session{{Password: "p", token: "t"}}}
+
+	t.Run("Error Mode", func(t *testing.T) {
+		_, err := RedactStrict(account, isSensitive, redactValue)
+
+		var fieldErr *UnexportedFieldError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("Expected an UnexportedFieldError, got %v", err)
+		}
+		if fieldErr.Path != "Sessions[0].token" {
+			t.Errorf("Expected path Sessions[0].token, got %s", fieldErr.Path)
+		}
+	})
+
+	t.Run("Silent Mode", func(t *testing.T) {
+		result := Redact(account, isSensitive, redactValue)
+
+		if result.Sessions[0].token != "" {
+			t.Errorf("Expected unexported field to be zeroed silently, got %s", result.Sessions[0].token)
+		}
+		if result.Sessions[0].Password != "***REDACTED***" {
+			t.Errorf("Expected Password to be redacted, got %s", result.Sessions[0].Password)
+		}
+	})
+
+	t.Run("Exported Only", func(t *testing.T) {
+		type Public struct {
+			Name     string
+			Password string
+		}
+
+		result, err := RedactStrict(Public{Name: "alice", Password: "p"}, isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Password != "***REDACTED***" || result.Name != "alice" {
+			t.Errorf("Expected Password to be redacted, got %+v", result)
+		}
+	})
+
+	t.Run("With Unexported", func(t *testing.T) {
+		result, err := RedactStrict(account, isSensitive, redactValue, WithUnexported())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Sessions[0].token != "t" {
+			t.Errorf("Expected unexported field to be copied, got %s", result.Sessions[0].token)
+		}
+	})
+}
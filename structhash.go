@@ -0,0 +1,51 @@
+package yaredact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// structureHashPrefix marks values produced by HashStructureRedactor
+const structureHashPrefix = "sha256:"
+
+// HashStructureRedactor returns a redactValue function that replaces string
+// values with "sha256:" followed by a hex prefix of their SHA-256, so a
+// changed secret shows up as a changed hash when diffing, without exposing
+// the secret. The hashes are unsalted and stable across runs, so low-entropy
+// secrets can be guessed by hashing candidates; use SaltedHashRedactor when
+// hashes don't need to be compared across runs. Non-string values pass
+// through.
+func HashStructureRedactor() func(any) any {
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		sum := sha256.Sum256([]byte(s))
+		return structureHashPrefix + hex.EncodeToString(sum[:hashedBytes])
+	}
+}
+
+// StructureHash returns a hex SHA-256 over the paths, types and values of
+// arg, flattened like RedactFlatten, for change detection. Pass it a value
+// redacted with HashStructureRedactor: the hash then changes whenever any
+// field changes, secret or not, without the secrets being hashed directly.
+func StructureHash[T any](arg T) string {
+	leaves := map[string]any{}
+	flattenValue(reflect.ValueOf(&arg).Elem(), "", leaves, references{})
+
+	paths := make([]string, 0, len(leaves))
+	for path := range leaves {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		fmt.Fprintf(h, "%q=%T:%v\n", path, leaves[path], leaves[path])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
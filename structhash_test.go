@@ -0,0 +1,70 @@
+package yaredact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructureHash(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	type Database struct {
+		Host     string
+		Port     int
+		Password string
+		Replicas []string
+	}
+
+	hash := func(db Database) string {
+		return StructureHash(Redact(db, isSensitive, HashStructureRedactor()))
+	}
+
+	base := Database{Host: "db.internal", Port: 5432, Password: "hunter2", Replicas: []string{"r1"}}
+
+	t.Run("Redactor", func(t *testing.T) {
+		redactValue := HashStructureRedactor()
+		hashed := redactValue("hunter2").(string)
+		if !strings.HasPrefix(hashed, "sha256:") || strings.Contains(hashed, "hunter2") {
+			t.Errorf("Expected a sha256 token, got %s", hashed)
+		}
+		if redactValue("hunter2") != hashed {
+			t.Errorf("Expected hashes to be stable")
+		}
+		if redactValue("hunter3") == hashed {
+			t.Errorf("Expected different secrets to hash differently")
+		}
+		if redactValue(42) != 42 {
+			t.Errorf("Expected non-string to pass through")
+		}
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		if hash(base) != hash(base) {
+			t.Errorf("Expected equal values to have equal structure hashes")
+		}
+	})
+
+	t.Run("NonSecretChange", func(t *testing.T) {
+		changed := base
+		changed.Port = 5433
+		if hash(changed) == hash(base) {
+			t.Errorf("Expected a changed non-secret field to change the structure hash")
+		}
+
+		changed = base
+		changed.Replicas = []string{"r1", "r2"}
+		if hash(changed) == hash(base) {
+			t.Errorf("Expected a changed slice to change the structure hash")
+		}
+	})
+
+	t.Run("SecretChange", func(t *testing.T) {
+		changed := base
+		changed.Password = "hunter3"
+		if hash(changed) == hash(base) {
+			t.Errorf("Expected a changed secret to change the structure hash")
+		}
+	})
+}
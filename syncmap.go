@@ -0,0 +1,41 @@
+package yaredact
+
+import (
+	"reflect"
+	"sync"
+)
+
+var syncMapType = reflect.TypeOf((*sync.Map)(nil)).Elem()
+
+// redactSyncMap redacts the entries of the sync.Map v at path like those of
+// a map[any]any, storing them in the addressable, unused sync.Map dst, which
+// must not be copied afterwards. Entries stored or deleted concurrently with
+// the redaction may or may not be seen, as with Range.
+func (w *walker) redactSyncMap(v reflect.Value, path string, dst reflect.Value) {
+	if !v.CanInterface() {
+		// Unexported sync.Map fields can't be ranged over, leave them empty
+		return
+	}
+	var source *sync.Map
+	if v.CanAddr() {
+		source = v.Addr().Interface().(*sync.Map)
+	} else {
+		source = new(sync.Map)
+		reflect.ValueOf(source).Elem().Set(v)
+	}
+
+	entries := map[any]any{}
+	source.Range(func(key, value any) bool {
+		entries[key] = value
+		return true
+	})
+	redacted := w.redactReflectValue(reflect.ValueOf(entries), path)
+	if !redacted.IsValid() || redacted.IsNil() {
+		return
+	}
+
+	target := dst.Addr().Interface().(*sync.Map)
+	for key, value := range redacted.Interface().(map[any]any) {
+		target.Store(key, value)
+	}
+}
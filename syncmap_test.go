@@ -0,0 +1,104 @@
+package yaredact
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedactSyncMap(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "password"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	type User struct {
+		Name     string
+		Password string
+	}
+
+	type Session struct {
+		ID    string
+		Cache sync.Map
+	}
+
+	t.Run("StructField", func(t *testing.T) {
+		session := &Session{ID: "s1"}
+		session.Cache.Store("password", "secret")
+		session.Cache.Store("user", User{Name: "alice", Password: "hunter2"})
+		session.Cache.Store(42, "answer")
+
+		result := Redact(session, isSensitive, redactValue)
+
+		if password, _ := result.Cache.Load("password"); password != "***REDACTED***" {
+			t.Errorf("Expected password entry to be redacted, got %v", password)
+		}
+		if user, _ := result.Cache.Load("user"); user != (User{Name: "alice", Password: "***REDACTED***"}) {
+			t.Errorf("Expected nested user to be redacted, got %v", user)
+		}
+		if answer, _ := result.Cache.Load(42); answer != "answer" {
+			t.Errorf("Expected non-sensitive entry to be kept, got %v", answer)
+		}
+		if password, _ := session.Cache.Load("password"); password != "secret" {
+			t.Errorf("Original was modified")
+		}
+	})
+
+	t.Run("Pointer", func(t *testing.T) {
+		cache := &sync.Map{}
+		cache.Store("password", "secret")
+
+		result := Redact(cache, isSensitive, redactValue)
+
+		if result == cache {
+			t.Fatalf("Expected a new sync.Map")
+		}
+		if password, _ := result.Load("password"); password != "***REDACTED***" {
+			t.Errorf("Expected password entry to be redacted, got %v", password)
+		}
+	})
+
+	t.Run("ArrayElements", func(t *testing.T) {
+		type Sharded struct {
+			Shards [2]sync.Map
+		}
+
+		sharded := &Sharded{}
+		sharded.Shards[0].Store("password", "secret")
+		sharded.Shards[1].Store("name", "alice")
+
+		result := Redact(sharded, isSensitive, redactValue)
+
+		if password, _ := result.Shards[0].Load("password"); password != "***REDACTED***" {
+			t.Errorf("Expected password entry to be redacted, got %v", password)
+		}
+		if name, _ := result.Shards[1].Load("name"); name != "alice" {
+			t.Errorf("Expected non-sensitive entry to be kept, got %v", name)
+		}
+
+		// The redacted maps are filled in place and remain usable
+		result.Shards[0].Store("token", "t")
+		if _, ok := sharded.Shards[0].Load("token"); ok {
+			t.Errorf("Expected the redacted map not to share entries with the original")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		result := Redact(&Session{ID: "s1"}, isSensitive, redactValue)
+
+		count := 0
+		result.Cache.Range(func(key, value any) bool {
+			count++
+			return true
+		})
+		if count != 0 || result.ID != "s1" {
+			t.Errorf("Expected an empty cache, got %d entries", count)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package yaredact
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// isTextType reports whether values of type t round-trip through text, like
+// time.Time, net.IP or UUID types. Such values are treated as atomic leaves.
+func isTextType(t reflect.Type) bool {
+	return traitsOf(t)&traitText != 0
+}
+
+// implementsText computes isTextType for traitsOf
+func implementsText(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr {
+		return false
+	}
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(textMarshalerType) && ptr.Implements(textUnmarshalerType)
+}
+
+// isStringerLeaf reports whether t is an opaque struct type, one without
+// exported fields that implements fmt.Stringer, like custom IDs. Such values
+// are atomic leaves: copied verbatim instead of having their unexported
+// fields zeroed, and handed to redactValue whole when sensitive.
+func isStringerLeaf(t reflect.Type) bool {
+	return traitsOf(t)&traitStringerLeaf != 0
+}
+
+// implementsStringerLeaf computes isStringerLeaf for traitsOf
+func implementsStringerLeaf(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !reflect.PointerTo(t).Implements(stringerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// redactText marshals v at path to text, passes the text to redactValue and
+// unmarshals the result back into v's type. If the redacted text can't be
+// unmarshaled, the zero value is returned so the original never leaks.
+// An invalid value is returned if redactValue returned Drop.
+func (w *walker) redactText(v reflect.Value, path string) reflect.Value {
+	original := reflect.New(v.Type())
+	original.Elem().Set(v)
+	text, err := original.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return reflect.Zero(v.Type())
+	}
+
+	redactedValue := w.redact(path, string(text))
+	if redactedValue == Drop {
+		return reflect.Value{}
+	}
+	redacted, ok := redactedValue.(string)
+	if !ok {
+		return reflect.Zero(v.Type())
+	}
+
+	result := reflect.New(v.Type())
+	if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(redacted)); err != nil {
+		return reflect.Zero(v.Type())
+	}
+	return result.Elem()
+}
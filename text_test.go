@@ -0,0 +1,164 @@
+package yaredact
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// UUID mimics uuid.UUID: an array type with text marshaling
+type UUID [16]byte
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(u[:])), nil
+}
+
+func (u *UUID) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil || len(b) != len(u) {
+		return errors.New("invalid uuid")
+	}
+	copy(u[:], b)
+	return nil
+}
+
+func TestTextMarshalers(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "sessionid" || lower == "clientip"
+	}
+
+	type Session struct {
+		RequestID UUID
+		SessionID UUID
+		ServerIP  net.IP
+		ClientIP  net.IP
+		Started   time.Time
+	}
+
+	requestID := UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	sessionID := UUID{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	session := Session{
+		RequestID: requestID,
+		SessionID: sessionID,
+		ServerIP:  net.ParseIP("10.0.0.1"),
+		ClientIP:  net.ParseIP("192.168.1.20"),
+		Started:   started,
+	}
+
+	t.Run("Non-Sensitive Values Copied Verbatim", func(t *testing.T) {
+		result := Redact(session, isSensitive, MaskLastN(4))
+
+		if result.RequestID != requestID {
+			t.Errorf("Expected RequestID to be unchanged, got %v", result.RequestID)
+		}
+		if !result.ServerIP.Equal(net.ParseIP("10.0.0.1")) {
+			t.Errorf("Expected ServerIP to be unchanged, got %v", result.ServerIP)
+		}
+		if !result.Started.Equal(started) {
+			t.Errorf("Expected Started to be unchanged, got %v", result.Started)
+		}
+	})
+
+	t.Run("Sensitive Values Redacted Via Text", func(t *testing.T) {
+		// Zero all but the last octet of IPs, keep UUIDs parseable
+		redactValue := func(v any) any {
+			s, ok := v.(string)
+			if !ok {
+				return v
+			}
+			if ip := net.ParseIP(s); ip != nil {
+				return "0.0.0." + s[strings.LastIndex(s, ".")+1:]
+			}
+			return strings.Repeat("0", len(s)-4) + s[len(s)-4:]
+		}
+
+		result := Redact(session, isSensitive, redactValue)
+
+		if got := result.ClientIP.String(); got != "0.0.0.20" {
+			t.Errorf("Expected ClientIP to be redacted, got %s", got)
+		}
+		if got, _ := result.SessionID.MarshalText(); string(got) != strings.Repeat("0", 28)+"0201" {
+			t.Errorf("Expected SessionID to be redacted, got %s", got)
+		}
+		if session.SessionID != sessionID {
+			t.Errorf("Original SessionID was modified")
+		}
+	})
+
+	t.Run("Unparseable Redaction Yields Zero Value", func(t *testing.T) {
+		result := Redact(session, isSensitive, MaskLastN(4))
+
+		if result.SessionID != (UUID{}) {
+			t.Errorf("Expected SessionID to be zeroed, got %v", result.SessionID)
+		}
+		if result.ClientIP != nil {
+			t.Errorf("Expected ClientIP to be zeroed, got %v", result.ClientIP)
+		}
+	})
+}
+
+// OrderID is an opaque ID that only implements fmt.Stringer
+type OrderID struct {
+	prefix string
+	n      int
+}
+
+func (id OrderID) String() string {
+	return fmt.Sprintf("%s-%d", id.prefix, id.n)
+}
+
+func TestStringerLeaves(t *testing.T) {
+	isSensitive := func(name string) bool {
+		return strings.ToLower(name) == "secret"
+	}
+
+	type Order struct {
+		ID     OrderID
+		Refs   []OrderID
+		Secret OrderID
+	}
+
+	order := Order{
+		ID:     OrderID{prefix: "ord", n: 1},
+		Refs:   []OrderID{{prefix: "ref", n: 2}},
+		Secret: OrderID{prefix: "sec", n: 3},
+	}
+
+	t.Run("Copied Verbatim", func(t *testing.T) {
+		result := Redact(order, isSensitive, func(v any) any { return v })
+
+		if result.ID != order.ID || result.Refs[0] != order.Refs[0] {
+			t.Errorf("Expected Stringer values to be copied with their unexported fields, got %+v", result)
+		}
+	})
+
+	t.Run("Sensitive Values Redacted Whole", func(t *testing.T) {
+		var received any
+		redactValue := func(v any) any {
+			if id, ok := v.(OrderID); ok {
+				received = v
+				return OrderID{prefix: id.prefix}
+			}
+			return v
+		}
+
+		result := Redact(order, isSensitive, redactValue)
+
+		if received != order.Secret {
+			t.Errorf("Expected redactValue to receive the whole OrderID, got %#v", received)
+		}
+		if result.Secret != (OrderID{prefix: "sec"}) || result.Secret.String() != "sec-0" {
+			t.Errorf("Expected Secret to be replaced, got %v", result.Secret)
+		}
+		if result.ID != order.ID {
+			t.Errorf("Expected ID to be kept, got %v", result.ID)
+		}
+	})
+}
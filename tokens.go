@@ -0,0 +1,39 @@
+package yaredact
+
+import (
+	"strconv"
+	"sync"
+)
+
+// RedactWithTokens is like Redact but replaces each distinct sensitive string
+// with a stable placeholder, "<secret#1>", "<secret#2>" and so on, so equal
+// secrets can be correlated across log lines. It also returns the map from
+// placeholders to the originals.
+//
+// The map holds the secrets in clear text: keep it out of logs, and discard
+// it as soon as the debugging session that needs it is over. Non-string
+// sensitive values are kept as-is; numbering follows traversal order, which
+// is random for map entries.
+func RedactWithTokens[T any](arg T, isSensitive func(string) bool, opts ...Option) (T, map[string]string) {
+	var mu sync.Mutex
+	tokens := map[string]string{}
+	originals := map[string]string{}
+
+	redactValue := func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		token, ok := tokens[s]
+		if !ok {
+			token = "<secret#" + strconv.Itoa(len(tokens)+1) + ">"
+			tokens[s] = token
+			originals[token] = s
+		}
+		return token
+	}
+
+	return Redact(arg, isSensitive, redactValue, opts...), originals
+}
@@ -0,0 +1,54 @@
+package yaredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactWithTokens(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token"
+	}
+
+	type Login struct {
+		User     string
+		Password string
+		Token    string
+		Attempts int
+	}
+
+	logins := []Login{
+		{User: "alice", Password: "hunter2", Token: "abc"},
+		{User: "bob", Password: "hunter2", Token: "xyz", Attempts: 3},
+	}
+
+	result, originals := RedactWithTokens(logins, isSensitive)
+
+	expected := []Login{
+		{User: "alice", Password: "<secret#1>", Token: "<secret#2>"},
+		{User: "bob", Password: "<secret#1>", Token: "<secret#3>", Attempts: 3},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+
+	expectedOriginals := map[string]string{
+		"<secret#1>": "hunter2",
+		"<secret#2>": "abc",
+		"<secret#3>": "xyz",
+	}
+	if !reflect.DeepEqual(originals, expectedOriginals) {
+		t.Errorf("Expected %v, got %v", expectedOriginals, originals)
+	}
+
+	// Each call has its own numbering
+	again, _ := RedactWithTokens(logins[1:], isSensitive)
+	if again[0].Password != "<secret#1>" || again[0].Token != "<secret#2>" {
+		t.Errorf("Expected numbering to restart per call, got %+v", again[0])
+	}
+	if logins[0].Password != "hunter2" {
+		t.Errorf("Original was modified")
+	}
+}
@@ -0,0 +1,64 @@
+package yaredact
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// typeTraits records which special kinds of leaf a type is, so the
+// reflection checks behind them run once per type rather than per value
+type typeTraits uint8
+
+const (
+	traitNoRedact typeTraits = 1 << iota
+	traitText
+	traitStringerLeaf
+)
+
+// traitCache maps types to their traits. It is shared by every redaction,
+// since traits depend on the type alone. Lookups are lock-free: a new type
+// replaces the map with a copy including it, and the map starts over once
+// it holds maxCachedTypes types.
+var traitCache struct {
+	mu      sync.Mutex
+	entries atomic.Pointer[map[reflect.Type]typeTraits]
+}
+
+// traitsOf returns the traits of t
+func traitsOf(t reflect.Type) typeTraits {
+	// Unnamed basic types such as string or int have no methods
+	if isBasicKind(t.Kind()) && t.Name() == "" {
+		return 0
+	}
+
+	entries := traitCache.entries.Load()
+	if entries != nil {
+		if traits, ok := (*entries)[t]; ok {
+			return traits
+		}
+	}
+
+	var traits typeTraits
+	if implementsNoRedact(t) {
+		traits |= traitNoRedact
+	}
+	if implementsText(t) {
+		traits |= traitText
+	}
+	if implementsStringerLeaf(t) {
+		traits |= traitStringerLeaf
+	}
+
+	traitCache.mu.Lock()
+	defer traitCache.mu.Unlock()
+	updated := map[reflect.Type]typeTraits{}
+	if current := traitCache.entries.Load(); current != nil && len(*current) < maxCachedTypes {
+		for known, knownTraits := range *current {
+			updated[known] = knownTraits
+		}
+	}
+	updated[t] = traits
+	traitCache.entries.Store(&updated)
+	return traits
+}
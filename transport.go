@@ -0,0 +1,97 @@
+package yaredact
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// redactingTransport is the http.RoundTripper returned by RedactingTransport
+type redactingTransport struct {
+	next        http.RoundTripper
+	isSensitive func(string) bool
+	redactValue func(any) any
+	opts        []Option
+	logf        func(format string, args ...any)
+}
+
+// WithLogger sets the function RedactingTransport logs redacted bodies with,
+// e.g. a *log.Logger's Printf or testing.T.Logf. It defaults to log.Printf.
+func WithLogger(logf func(format string, args ...any)) Option {
+	return func(c *config) {
+		c.logf = logf
+	}
+}
+
+// RedactingTransport returns an http.RoundTripper that logs JSON request and
+// response bodies after redacting them with RedactJSON and opts. Bodies are
+// logged with the standard log package unless WithLogger is used. The
+// original bodies are passed through unchanged. Bodies that aren't JSON, by
+// Content-Type, or fail to parse are not logged. A nil next uses
+// http.DefaultTransport.
+//
+// JSON bodies are read fully into memory before they are sent, and before
+// the response is returned to the caller, so the transport doesn't suit
+// streamed or very large JSON bodies.
+func RedactingTransport(next http.RoundTripper, isSensitive func(string) bool, redactValue func(any) any, opts ...Option) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	logf := newConfig(isSensitive, redactValue, opts).logf
+	if logf == nil {
+		logf = log.Printf
+	}
+	return &redactingTransport{next: next, isSensitive: isSensitive, redactValue: redactValue, opts: opts, logf: logf}
+}
+
+func (t *redactingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && isJSONContentType(req.Header.Get("Content-Type")) {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// RoundTrip must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		t.logBody(req.Method+" "+req.URL.String()+" request", body)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Body != nil && isJSONContentType(resp.Header.Get("Content-Type")) {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.logBody(req.Method+" "+req.URL.String()+" response "+resp.Status, body)
+	}
+	return resp, nil
+}
+
+// logBody logs the redacted body, skipping bodies that aren't valid JSON
+func (t *redactingTransport) logBody(prefix string, body []byte) {
+	redacted, err := RedactJSON(body, t.isSensitive, t.redactValue, t.opts...)
+	if err != nil {
+		return
+	}
+	t.logf("yaredact: %s: %s", prefix, redacted)
+}
+
+// isJSONContentType reports whether a Content-Type header denotes JSON,
+// e.g. application/json or application/problem+json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
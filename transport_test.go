@@ -0,0 +1,133 @@
+package yaredact
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRedactingTransport(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	var received string
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		received = string(body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			Body:       io.NopCloser(strings.NewReader(`{"token":"abc123"}`)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: RedactingTransport(next, isSensitive, redactValue)}
+
+	t.Run("JSON Bodies", func(t *testing.T) {
+		logs.Reset()
+		resp, err := client.Post("http://example.test/login", "application/json", strings.NewReader(`{"user":"alice","password":"hunter2"}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer resp.Body.Close()
+
+		if received != `{"user":"alice","password":"hunter2"}` {
+			t.Errorf("Expected original request body to be sent, got %s", received)
+		}
+		if body, _ := io.ReadAll(resp.Body); string(body) != `{"token":"abc123"}` {
+			t.Errorf("Expected original response body to be returned, got %s", body)
+		}
+
+		output := logs.String()
+		if strings.Contains(output, "hunter2") || strings.Contains(output, "abc123") {
+			t.Errorf("Expected secrets not to be logged, got %s", output)
+		}
+		if !strings.Contains(output, `"password":"***REDACTED***"`) || !strings.Contains(output, `"token":"***REDACTED***"`) {
+			t.Errorf("Expected redacted bodies to be logged, got %s", output)
+		}
+	})
+
+	t.Run("Non-JSON Body", func(t *testing.T) {
+		logs.Reset()
+		resp, err := client.Post("http://example.test/upload", "text/plain", strings.NewReader("password=hunter2"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp.Body.Close()
+
+		if received != "password=hunter2" {
+			t.Errorf("Expected original request body to be sent, got %s", received)
+		}
+		if strings.Contains(logs.String(), "hunter2") || strings.Contains(logs.String(), "request") {
+			t.Errorf("Expected non-JSON request body not to be logged, got %s", logs.String())
+		}
+	})
+
+	t.Run("Response Body Read Error", func(t *testing.T) {
+		failing := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(iotest.ErrReader(errors.New("connection reset"))),
+			}, nil
+		})
+
+		resp, err := RedactingTransport(failing, isSensitive, redactValue).RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.test/", nil))
+		if err == nil {
+			t.Fatalf("Expected the read error to be returned")
+		}
+		if resp != nil {
+			t.Errorf("Expected no response alongside the error, got %v", resp)
+		}
+	})
+	t.Run("Logger Option", func(t *testing.T) {
+		logs.Reset()
+		var logged []string
+		logf := func(format string, args ...any) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		}
+		client := &http.Client{Transport: RedactingTransport(next, isSensitive, redactValue, WithLogger(logf))}
+
+		resp, err := client.Post("http://example.test/login", "application/json", strings.NewReader(`{"password":"hunter2"}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp.Body.Close()
+
+		if len(logged) != 2 || !strings.Contains(logged[0], `"password":"***REDACTED***"`) {
+			t.Errorf("Expected request and response to be logged through the logger, got %v", logged)
+		}
+		if logs.Len() != 0 {
+			t.Errorf("Expected nothing on the standard logger, got %s", logs.String())
+		}
+	})
+}
@@ -0,0 +1,14 @@
+module github.com/choonkeat/ya-redact-go/yamlredact
+
+go 1.22.3
+
+// v0.2.0 is the first release of the parent module with RedactAny options.
+// Tag it before tagging yamlredact/v0.2.0, which requires it.
+require github.com/choonkeat/ya-redact-go v0.2.0
+
+require gopkg.in/yaml.v3 v3.0.1
+
+// Builds inside this repository use the parent module from disk. Go ignores
+// this directive for projects depending on yamlredact, which get the tagged
+// version required above.
+replace github.com/choonkeat/ya-redact-go => ../
@@ -0,0 +1,41 @@
+// Package yamlredact redacts YAML documents with yaredact. It is a separate
+// module so the core library stays free of dependencies.
+package yamlredact
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	yaredact "github.com/choonkeat/ya-redact-go"
+	"gopkg.in/yaml.v3"
+)
+
+// RedactYAML decodes every document of a YAML stream into maps and slices,
+// redacts values under sensitive keys like yaredact.Redact and re-encodes the
+// documents, separated by "---". Key order and comments are not preserved.
+// Invalid YAML returns the decoding error.
+func RedactYAML(data []byte, isSensitive func(string) bool, redactValue func(any) any, opts ...yaredact.Option) ([]byte, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for {
+		var doc any
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(yaredact.RedactAny(doc, isSensitive, redactValue, opts...)); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,82 @@
+package yamlredact
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRedactYAML(t *testing.T) {
+	isSensitive := func(name string) bool {
+		lower := strings.ToLower(name)
+		return lower == "password" || lower == "token"
+	}
+
+	redactValue := func(v any) any {
+		if _, ok := v.(string); ok {
+			return "***REDACTED***"
+		}
+		return v
+	}
+
+	t.Run("Nested Config", func(t *testing.T) {
+		input := `
+database:
+  host: db.internal
+  port: 5432
+  password: hunter2
+replicas:
+  - host: r1
+    password: r1-pass
+`
+		result, err := RedactYAML([]byte(input), isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var decoded map[string]any
+		if err := yaml.Unmarshal(result, &decoded); err != nil {
+			t.Fatalf("Expected valid YAML, got %v", err)
+		}
+		database := decoded["database"].(map[string]any)
+		if database["password"] != "***REDACTED***" {
+			t.Errorf("Expected password to be redacted, got %v", database["password"])
+		}
+		if database["host"] != "db.internal" || database["port"] != 5432 {
+			t.Errorf("Expected other values to be kept, got %v", database)
+		}
+		replica := decoded["replicas"].([]any)[0].(map[string]any)
+		if replica["password"] != "***REDACTED***" {
+			t.Errorf("Expected replica password to be redacted, got %v", replica["password"])
+		}
+	})
+
+	t.Run("Multiple Documents", func(t *testing.T) {
+		input := "token: a\nname: first\n---\ntoken: b\nname: second\n"
+
+		result, err := RedactYAML([]byte(input), isSensitive, redactValue)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		docs := strings.Split(string(result), "---\n")
+		if len(docs) != 2 {
+			t.Fatalf("Expected 2 documents, got %d: %s", len(docs), result)
+		}
+		for i, doc := range docs {
+			if strings.Contains(doc, "token: a") || strings.Contains(doc, "token: b") {
+				t.Errorf("Expected token in document %d to be redacted, got %s", i, doc)
+			}
+		}
+		if !strings.Contains(docs[1], "name: second") {
+			t.Errorf("Expected second document to be kept, got %s", docs[1])
+		}
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		if _, err := RedactYAML([]byte("a: [b"), isSensitive, redactValue); err == nil {
+			t.Errorf("Expected an error for invalid YAML")
+		}
+	})
+}